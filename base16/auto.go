@@ -0,0 +1,125 @@
+package base16
+
+import "sort"
+
+// accentHues are the OKLCH hues base16 expects for its accent slots.
+var accentHues = []struct {
+	slot Slot
+	hue  float64
+}{
+	{Base08, 25},  // red
+	{Base09, 55},  // orange
+	{Base0A, 95},  // yellow
+	{Base0B, 145}, // green
+	{Base0C, 195}, // cyan
+	{Base0D, 255}, // blue
+	{Base0E, 320}, // magenta
+}
+
+// rampSteps place base01-base04 between the background (0) and the
+// foreground (1).
+var rampSteps = []struct {
+	slot Slot
+	t    float64
+}{
+	{Base01, 0.08},
+	{Base02, 0.16},
+	{Base03, 0.45},
+	{Base04, 0.75},
+}
+
+// minChroma is the OKLCH chroma below which a color counts as grey and is
+// never used as an accent.
+const minChroma = 0.03
+
+// grayRamp returns base00-base07 for a scheme with background bg and
+// foreground fg: base01-base04 step from one to the other, and base06 and
+// base07 carry on past fg towards black or white.
+func grayRamp(bg, fg Color) [8]Color {
+	var ramp [8]Color
+	bgLab, fgLab := toOKLab(bg), toOKLab(fg)
+	ramp[Base00] = bg
+	ramp[Base05] = fg
+	for _, step := range rampSteps {
+		ramp[step.slot] = bgLab.mix(fgLab, step.t).color()
+	}
+	extreme := oklab{L: 1}
+	if fgLab.L < bgLab.L {
+		extreme = oklab{L: 0}
+	}
+	ramp[Base06] = fgLab.mix(extreme, 0.3).color()
+	ramp[Base07] = fgLab.mix(extreme, 0.6).color()
+	return ramp
+}
+
+// autoAssign fills every slot by classifying the terminal palette rather
+// than by fixed indices. The greys come from a lightness ramp between the
+// background and foreground; each accent takes the unused palette color
+// closest in hue.
+func autoAssign(t Terminal) [NumSlots]Color {
+	var colors [NumSlots]Color
+
+	ramp := grayRamp(t.Background, t.Foreground)
+	copy(colors[:], ramp[:])
+
+	type candidate struct {
+		index  int
+		lab    oklab
+		chroma float64
+		hue    float64
+	}
+	var cands []candidate
+	for i, c := range t.Colors {
+		lab := toOKLab(c)
+		_, chroma, hue := lab.lch()
+		if chroma >= minChroma {
+			cands = append(cands, candidate{i, lab, chroma, hue})
+		}
+	}
+
+	type pairing struct {
+		accent, cand int
+		cost         float64
+	}
+	var pairs []pairing
+	for a, accent := range accentHues {
+		for c, cand := range cands {
+			pairs = append(pairs, pairing{a, c, hueDistance(accent.hue, cand.hue)})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].cost < pairs[j].cost })
+
+	assigned := make([]bool, len(accentHues))
+	used := make([]bool, len(cands))
+	var sumL, sumC float64
+	n := 0
+	for _, p := range pairs {
+		if assigned[p.accent] || used[p.cand] {
+			continue
+		}
+		assigned[p.accent], used[p.cand] = true, true
+		cand := cands[p.cand]
+		colors[accentHues[p.accent].slot] = t.Colors[cand.index]
+		sumL += cand.lab.L
+		sumC += cand.chroma
+		n++
+	}
+
+	// Palettes with fewer chromatic colors than accents get the missing hues
+	// synthesized at the average lightness and chroma of the others.
+	l, c := 0.7, 0.12
+	if n > 0 {
+		l, c = sumL/float64(n), sumC/float64(n)
+	}
+	for a, ok := range assigned {
+		if !ok {
+			colors[accentHues[a].slot] = fromLCH(l, c, accentHues[a].hue).color()
+		}
+	}
+
+	// base0F is conventionally a darker, muted orange or brown.
+	ol, oc, oh := toOKLab(colors[Base09]).lch()
+	colors[Base0F] = fromLCH(ol*0.8, oc*0.75, oh).color()
+
+	return colors
+}
@@ -0,0 +1,83 @@
+package base16
+
+import "testing"
+
+func mustColor(t *testing.T, s string) Color {
+	t.Helper()
+	c, err := ParseColor(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestAutoAssignShuffled(t *testing.T) {
+	accents := map[Slot]string{
+		Base08: "#cc3333", // red
+		Base09: "#dd7722", // orange
+		Base0A: "#ddcc33", // yellow
+		Base0B: "#44aa44", // green
+		Base0C: "#33aaaa", // cyan
+		Base0D: "#3366cc", // blue
+		Base0E: "#aa44aa", // magenta
+	}
+	// Accents in an order unrelated to ANSI's, among greys.
+	order := []string{
+		"#202020", accents[Base0D], accents[Base0B], "#808080", accents[Base08], accents[Base0E], "#c0c0c0", accents[Base09],
+		"#404040", accents[Base0C], "#a0a0a0", accents[Base0A], "#606060", "#e0e0e0", "#303030", "#f0f0f0",
+	}
+	term := Terminal{Background: mustColor(t, "#101010"), Foreground: mustColor(t, "#d0d0d0")}
+	for i, hex := range order {
+		term.Colors[i] = mustColor(t, hex)
+	}
+
+	colors := autoAssign(term)
+	for slot, hex := range accents {
+		if got := colors[slot]; got != mustColor(t, hex) {
+			t.Errorf("%s = %v, want %s", slot, got, hex)
+		}
+	}
+}
+
+func TestAutoAssignSynthesizesMissingHues(t *testing.T) {
+	term := Terminal{Background: mustColor(t, "#101010"), Foreground: mustColor(t, "#d0d0d0")}
+	for i := range term.Colors {
+		v := uint8(0x10 * i)
+		term.Colors[i] = Color{v, v, v, 0xff}
+	}
+	term.Colors[1] = mustColor(t, "#cc3333")
+	term.Colors[4] = mustColor(t, "#3366cc")
+
+	colors := autoAssign(term)
+	if colors[Base08] != term.Colors[1] || colors[Base0D] != term.Colors[4] {
+		t.Errorf("base08 %v, base0D %v; want the palette's red and blue", colors[Base08], colors[Base0D])
+	}
+	for _, a := range accentHues {
+		if a.slot == Base08 || a.slot == Base0D {
+			continue
+		}
+		_, c, h := toOKLab(colors[a.slot]).lch()
+		if c < minChroma || hueDistance(h, a.hue) > 15 {
+			t.Errorf("%s = %v has chroma %.3f and hue %.0f, want about %.0f", a.slot, colors[a.slot], c, h, a.hue)
+		}
+	}
+}
+
+func TestGrayRamp(t *testing.T) {
+	for _, tt := range []struct{ bg, fg string }{{"#1d1f21", "#c5c8c6"}, {"#fafafa", "#383a42"}} {
+		ramp := grayRamp(mustColor(t, tt.bg), mustColor(t, tt.fg))
+		if ramp[Base00] != mustColor(t, tt.bg) || ramp[Base05] != mustColor(t, tt.fg) {
+			t.Errorf("%s to %s: base00 %v, base05 %v", tt.bg, tt.fg, ramp[Base00], ramp[Base05])
+		}
+		s := Scheme{}
+		copy(s.Colors[:], ramp[:])
+		if !s.rampIsMonotonic() {
+			t.Errorf("%s to %s: ramp is not monotonic: %v", tt.bg, tt.fg, ramp)
+		}
+		for i := 1; i < 6; i++ {
+			if toOKLab(ramp[i]).L == toOKLab(ramp[i-1]).L {
+				t.Errorf("%s to %s: base0%d and base0%d are equally light", tt.bg, tt.fg, i-1, i)
+			}
+		}
+	}
+}
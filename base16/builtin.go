@@ -0,0 +1,123 @@
+package base16
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//go:embed templates/*.mustache
+var builtinTemplates embed.FS
+
+// BuiltinTemplateNames lists the templates shipped with the package.
+func BuiltinTemplateNames() []string {
+	entries, _ := builtinTemplates.ReadDir("templates")
+	var names []string
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".mustache"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuiltinTemplate returns the source of the named built-in template.
+func BuiltinTemplate(name string) (string, error) {
+	data, err := builtinTemplates.ReadFile("templates/" + name + ".mustache")
+	if err != nil {
+		return "", fmt.Errorf("no built-in template %q; choose one of %s", name, strings.Join(BuiltinTemplateNames(), ", "))
+	}
+	return string(data), nil
+}
+
+// LoadTemplate returns the source of the named built-in template, or of
+// <name>.mustache in overrideDir if that file exists.
+func LoadTemplate(name, overrideDir string) (string, error) {
+	if overrideDir != "" {
+		data, err := os.ReadFile(filepath.Join(overrideDir, name+".mustache"))
+		if err == nil {
+			return string(data), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	return BuiltinTemplate(name)
+}
+
+// GenerateNeovim renders s as a base16-vim colorscheme.
+func GenerateNeovim(s Scheme, opts NeovimOptions) ([]byte, error) {
+	vars, err := NeovimVars(s, opts)
+	if err != nil {
+		return nil, err
+	}
+	return generateBuiltin("neovim", vars)
+}
+
+// GenerateNeovimLua renders s as a Lua colorscheme for neovim, using
+// nvim_set_hl.
+func GenerateNeovimLua(s Scheme, opts NeovimOptions) ([]byte, error) {
+	vars, err := NeovimVars(s, opts)
+	if err != nil {
+		return nil, err
+	}
+	return generateBuiltin("neovim-lua", vars)
+}
+
+// GenerateShell renders s as a base16-shell script.
+func GenerateShell(s Scheme) ([]byte, error) {
+	return generateBuiltin("shell", TemplateVars(s))
+}
+
+// GenerateAlacritty renders s as an Alacritty TOML color import.
+func GenerateAlacritty(s Scheme) ([]byte, error) {
+	return generateBuiltin("alacritty", TemplateVars(s))
+}
+
+// GenerateKitty renders s as a kitty theme .conf.
+func GenerateKitty(s Scheme) ([]byte, error) {
+	return generateBuiltin("kitty", TemplateVars(s))
+}
+
+// GenerateWezTerm renders s as a WezTerm color scheme TOML file named
+// base16-<slug>.
+func GenerateWezTerm(s Scheme) ([]byte, error) {
+	return generateBuiltin("wezterm", WezTermVars(s, "base16-"+s.Slug))
+}
+
+// WezTermVars returns TemplateVars plus the scheme's name in WezTerm, which
+// should match the file's name, as colors-name, and its author as
+// colors-author, both escaped for a TOML string.
+func WezTermVars(s Scheme, name string) map[string]any {
+	vars := TemplateVars(s)
+	vars["colors-name"] = escapeTOML(name)
+	vars["colors-author"] = escapeTOML(s.Author)
+	return vars
+}
+
+// GenerateFoot renders s as a foot.ini [colors] theme.
+func GenerateFoot(s Scheme) ([]byte, error) {
+	return generateBuiltin("foot", TemplateVars(s))
+}
+
+// GenerateGhostty renders s as a Ghostty theme.
+func GenerateGhostty(s Scheme) ([]byte, error) {
+	return generateBuiltin("ghostty", TemplateVars(s))
+}
+
+// GenerateITerm2 renders s as an iTerm2 .itermcolors property list.
+func GenerateITerm2(s Scheme) ([]byte, error) {
+	return generateBuiltin("iterm2", TemplateVars(s))
+}
+
+func generateBuiltin(name string, vars map[string]any) ([]byte, error) {
+	src, err := BuiltinTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+	return RenderTemplateVars(src, vars)
+}
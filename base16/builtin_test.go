@@ -0,0 +1,261 @@
+package base16
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+// tomorrowNight is a terminal.sexy export of Tomorrow Night.
+var tomorrowNight = Base16JSON{
+	Name:   "Tomorrow Night",
+	Author: "Chris Kempson",
+	Color: []string{
+		"#1d1f21", "#cc6666", "#b5bd68", "#f0c674", "#81a2be", "#b294bb", "#8abeb7", "#c5c8c6",
+		"#969896", "#d54e53", "#b9ca4a", "#e7c547", "#7aa6da", "#c397d8", "#70c0b1", "#eaeaea",
+	},
+	Foreground: "#c5c8c6",
+	Background: "#1d1f21",
+}
+
+func testScheme(t *testing.T, mapping string) Scheme {
+	t.Helper()
+	m, err := BuiltinMapping(mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := FromJSONMapping(tomorrowNight, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// hasLines fails t for every line of want missing from out.
+func hasLines(t *testing.T, name string, out []byte, want ...string) {
+	t.Helper()
+	lines := strings.Split(string(out), "\n")
+	for _, w := range want {
+		found := false
+		for _, l := range lines {
+			if l == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%s: missing line %q", name, w)
+		}
+	}
+}
+
+func TestGenerateShell(t *testing.T) {
+	s := testScheme(t, "auto")
+	out, err := GenerateShell(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slash := func(slot Slot) string { return `"` + s.Colors[slot].Slash() + `"` }
+	hasLines(t, "shell", out,
+		`color16=`+slash(Base09)+` # Base 09`,
+		`color17=`+slash(Base0F)+` # Base 0F`,
+		`color18=`+slash(Base01)+` # Base 01`,
+		`color19=`+slash(Base02)+` # Base 02`,
+		`color20=`+slash(Base04)+` # Base 04`,
+		`color21=`+slash(Base06)+` # Base 06`,
+	)
+}
+
+func TestGenerateAlacritty(t *testing.T) {
+	out, err := GenerateAlacritty(testScheme(t, "default"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasLines(t, "alacritty", out,
+		`[colors.primary]`,
+		`background = "#1d1f21"`,
+		`[colors.bright]`,
+		`black = "#969896"`,
+		`white = "#eaeaea"`,
+		`index = 21`,
+	)
+}
+
+func TestGenerateKitty(t *testing.T) {
+	out, err := GenerateKitty(testScheme(t, "default"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasLines(t, "kitty", out,
+		`foreground #c5c8c6`,
+		`selection_background #969896`,
+		`color0 #1d1f21`,
+		`color15 #eaeaea`,
+		`color16 #b9ca4a`,
+		`color21 #eaeaea`,
+	)
+}
+
+func TestGenerateWezTerm(t *testing.T) {
+	out, err := GenerateWezTerm(testScheme(t, "default"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasLines(t, "wezterm", out,
+		`[colors]`,
+		`cursor_bg = "#eaeaea"`,
+		`brights = [`,
+		`indexed = { 16 = "#b9ca4a", 17 = "#e7c547", 18 = "#1d1f21", 19 = "#c5c8c6", 20 = "#70c0b1", 21 = "#eaeaea" }`,
+		`name = "base16-tomorrow-night"`,
+		`author = "Chris Kempson"`,
+	)
+}
+
+func TestGenerateFoot(t *testing.T) {
+	out, err := GenerateFoot(testScheme(t, "default"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasLines(t, "foot", out,
+		`color=1d1f21 eaeaea`,
+		`selection-background=969896`,
+		`regular0=1d1f21`,
+		`bright7=eaeaea`,
+	)
+}
+
+func TestGenerateGhostty(t *testing.T) {
+	out, err := GenerateGhostty(testScheme(t, "default"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasLines(t, "ghostty", out,
+		`palette = 0=#1d1f21`,
+		`palette = 15=#eaeaea`,
+		`palette = 16=#b9ca4a`,
+		`palette = 21=#eaeaea`,
+		`cursor-color = #eaeaea`,
+	)
+}
+
+func TestGenerateITerm2(t *testing.T) {
+	out, err := GenerateITerm2(testScheme(t, "default"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := xml.NewDecoder(bytes.NewReader(out))
+	for {
+		if _, err := d.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("iterm2: invalid plist: %v", err)
+		}
+	}
+	// Ansi 1 is #cc6666.
+	want := "<key>Ansi 1 Color</key>\n\t<dict>\n\t\t<key>Alpha Component</key>\n\t\t<real>1</real>\n" +
+		"\t\t<key>Blue Component</key>\n\t\t<real>0.4</real>\n\t\t<key>Color Space</key>\n\t\t<string>sRGB</string>\n" +
+		"\t\t<key>Green Component</key>\n\t\t<real>0.4</real>\n\t\t<key>Red Component</key>\n\t\t<real>0.8</real>\n"
+	if !bytes.Contains(out, []byte(want)) {
+		t.Errorf("iterm2: Ansi 1 Color is not #cc6666:\n%s", out)
+	}
+}
+
+func TestGenerateWindowsTerminal(t *testing.T) {
+	out, err := GenerateWindowsTerminal(testScheme(t, "default"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Schemes []windowsTerminalScheme `json:"schemes"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("windows terminal: %v", err)
+	}
+	if len(doc.Schemes) != 1 {
+		t.Fatalf("windows terminal: %d schemes, want 1", len(doc.Schemes))
+	}
+	got := doc.Schemes[0]
+	if got.Name != "base16-tomorrow-night" || got.Background != "#1d1f21" || got.BrightBlack != "#969896" || got.BrightWhite != "#eaeaea" {
+		t.Errorf("windows terminal: %+v", got)
+	}
+}
+
+func TestGenerateWindowsTerminalNamed(t *testing.T) {
+	out, err := GenerateWindowsTerminalNamed(testScheme(t, "default"), "base16-my-night")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Schemes []windowsTerminalScheme `json:"schemes"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Schemes) != 1 || doc.Schemes[0].Name != "base16-my-night" {
+		t.Errorf("schemes = %+v, want one named base16-my-night", doc.Schemes)
+	}
+}
+
+// TestHeaderInjection renders a scheme whose name and author carry line
+// breaks and checks that the text after them never starts a line of its
+// own, where it would run as code or settings.
+func TestHeaderInjection(t *testing.T) {
+	s := testScheme(t, "default")
+	s.Name = "Evil\nvim.fn.system('touch /tmp/pwned-name')\r[pwned.section]"
+	s.Author = "Me\r\nos.execute('touch /tmp/pwned-author')"
+	injected := []string{"vim.fn.system(", "[pwned.section]", "os.execute("}
+
+	tests := []struct {
+		name string
+		gen  func(Scheme) ([]byte, error)
+	}{
+		{"neovim-lua", func(s Scheme) ([]byte, error) { return GenerateNeovimLua(s, NeovimOptions{}) }},
+		{"alacritty", GenerateAlacritty},
+		{"kitty", GenerateKitty},
+		{"wezterm", GenerateWezTerm},
+		{"foot", GenerateFoot},
+		{"ghostty", GenerateGhostty},
+	}
+	for _, tt := range tests {
+		out, err := tt.gen(s)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !bytes.Contains(out, []byte("pwned-name")) || !bytes.Contains(out, []byte("pwned-author")) {
+			t.Errorf("%s: scheme name or author missing from the output", tt.name)
+		}
+		lines := strings.FieldsFunc(string(out), func(r rune) bool { return r == '\n' || r == '\r' })
+		for _, l := range lines {
+			for _, bad := range injected {
+				if strings.HasPrefix(l, bad) {
+					t.Errorf("%s: text escaped its comment: %q", tt.name, l)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateNeovimWildMenu(t *testing.T) {
+	s := testScheme(t, "default")
+	out, err := GenerateNeovim(s, NeovimOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasLines(t, "neovim", out,
+		`call <sid>hi("Normal",        s:gui05, s:gui00, s:cterm05, s:cterm00, "", "")`,
+		`call <sid>hi("WildMenu",      s:gui08, s:gui0A, s:cterm08, "", "", "")`,
+	)
+
+	bg := Base02
+	out, err = GenerateNeovim(s, NeovimOptions{Overrides: []HighlightOverride{{Group: "WildMenu", Bg: &bg}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasLines(t, "neovim with override", out,
+		`call <sid>hi("WildMenu",      s:gui08, s:gui02, s:cterm08, s:cterm02, "", "")`,
+	)
+}
@@ -0,0 +1,307 @@
+package base16
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Color is an sRGB color with an alpha channel.
+type Color struct {
+	R, G, B, A uint8
+}
+
+// ParseError reports a color string that could not be parsed. Pos is the
+// byte offset in Input where parsing failed.
+type ParseError struct {
+	Input string
+	Pos   int
+	Msg   string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid color %q at position %d: %s", e.Input, e.Pos, e.Msg)
+}
+
+// ParseColor parses a CSS color: #RGB, #RRGGBB, #RRGGBBAA, rgb()/rgba(),
+// hsl()/hsla() or a named color.
+func ParseColor(s string) (Color, error) {
+	p := colorParser{input: s}
+	return p.parse()
+}
+
+// String returns the color as "#rrggbb".
+func (c Color) String() string {
+	return "#" + c.Hex()
+}
+
+// Hex returns the color as bare "rrggbb".
+func (c Color) Hex() string {
+	return fmt.Sprintf("%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// Slash returns the color as "rr/gg/bb", the form used by OSC escape codes.
+func (c Color) Slash() string {
+	return fmt.Sprintf("%02x/%02x/%02x", c.R, c.G, c.B)
+}
+
+// Ints returns the red, green and blue channels in the range 0-255.
+func (c Color) Ints() (r, g, b int) {
+	return int(c.R), int(c.G), int(c.B)
+}
+
+// Floats returns the red, green and blue channels in the range 0-1.
+func (c Color) Floats() (r, g, b float64) {
+	return float64(c.R) / 255, float64(c.G) / 255, float64(c.B) / 255
+}
+
+type colorParser struct {
+	input string
+	pos   int
+}
+
+func (p *colorParser) errorf(pos int, format string, args ...any) error {
+	return &ParseError{Input: p.input, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *colorParser) parse() (Color, error) {
+	p.skipSpace()
+	if p.pos == len(p.input) {
+		return Color{}, p.errorf(p.pos, "empty color")
+	}
+
+	var c Color
+	var err error
+	start := p.pos
+	switch {
+	case p.input[p.pos] == '#':
+		c, err = p.parseHex()
+	case isHexDigit(p.input[p.pos]) && strings.Trim(strings.TrimSpace(p.input[p.pos:]), "0123456789abcdefABCDEF") == "":
+		return Color{}, p.errorf(p.pos, "missing '#' before hex digits")
+	case isAlpha(p.input[p.pos]):
+		name := p.ident()
+		switch lower := strings.ToLower(name); lower {
+		case "rgb", "rgba":
+			c, err = p.parseRGB()
+		case "hsl", "hsla":
+			c, err = p.parseHSL()
+		default:
+			named, ok := namedColors[lower]
+			if !ok {
+				return Color{}, p.errorf(start, "unknown color name %q", name)
+			}
+			c = named
+		}
+	default:
+		return Color{}, p.errorf(p.pos, "expecting '#', a color function or a color name")
+	}
+	if err != nil {
+		return Color{}, err
+	}
+
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return Color{}, p.errorf(p.pos, "unexpected %q after color", p.input[p.pos:])
+	}
+	return c, nil
+}
+
+func (p *colorParser) parseHex() (Color, error) {
+	start := p.pos
+	p.pos++
+	digits := p.pos
+	for p.pos < len(p.input) && isHexDigit(p.input[p.pos]) {
+		p.pos++
+	}
+	if p.pos < len(p.input) && !isSpace(p.input[p.pos]) {
+		return Color{}, p.errorf(p.pos, "invalid hex digit %q", p.input[p.pos])
+	}
+
+	hex := p.input[digits:p.pos]
+	switch len(hex) {
+	case 3:
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]}) + "ff"
+	case 6:
+		hex += "ff"
+	case 8:
+	default:
+		return Color{}, p.errorf(start, "expecting 3, 6 or 8 hex digits, got %d", len(hex))
+	}
+
+	v, _ := strconv.ParseUint(hex, 16, 32)
+	return Color{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+func (p *colorParser) parseRGB() (Color, error) {
+	args, err := p.arguments()
+	if err != nil {
+		return Color{}, err
+	}
+	if len(args) != 3 && len(args) != 4 {
+		return Color{}, p.errorf(args[0].pos, "rgb() takes 3 or 4 arguments, got %d", len(args))
+	}
+
+	var ch [3]uint8
+	for i := range ch {
+		v, err := p.channel(args[i], 255)
+		if err != nil {
+			return Color{}, err
+		}
+		ch[i] = v
+	}
+	c := Color{R: ch[0], G: ch[1], B: ch[2], A: 255}
+	if len(args) == 4 {
+		if c.A, err = p.channel(args[3], 1); err != nil {
+			return Color{}, err
+		}
+	}
+	return c, nil
+}
+
+func (p *colorParser) parseHSL() (Color, error) {
+	args, err := p.arguments()
+	if err != nil {
+		return Color{}, err
+	}
+	if len(args) != 3 && len(args) != 4 {
+		return Color{}, p.errorf(args[0].pos, "hsl() takes 3 or 4 arguments, got %d", len(args))
+	}
+
+	h, err := p.number(strings.TrimSuffix(args[0].text, "deg"), args[0].pos)
+	if err != nil {
+		return Color{}, err
+	}
+	var sl [2]float64
+	for i := range sl {
+		a := args[i+1]
+		if !strings.HasSuffix(a.text, "%") {
+			return Color{}, p.errorf(a.pos, "expecting a percentage")
+		}
+		v, err := p.number(strings.TrimSuffix(a.text, "%"), a.pos)
+		if err != nil {
+			return Color{}, err
+		}
+		if v < 0 || v > 100 {
+			return Color{}, p.errorf(a.pos, "%s is out of range 0%%-100%%", a.text)
+		}
+		sl[i] = v / 100
+	}
+
+	r, g, b := hslToRGB(h, sl[0], sl[1])
+	c := Color{R: toByte(r), G: toByte(g), B: toByte(b), A: 255}
+	if len(args) == 4 {
+		if c.A, err = p.channel(args[3], 1); err != nil {
+			return Color{}, err
+		}
+	}
+	return c, nil
+}
+
+type argument struct {
+	text string
+	pos  int
+}
+
+// arguments reads a parenthesised, comma or space separated argument list.
+func (p *colorParser) arguments() ([]argument, error) {
+	p.skipSpace()
+	if p.pos == len(p.input) || p.input[p.pos] != '(' {
+		return nil, p.errorf(p.pos, "expecting '('")
+	}
+	p.pos++
+
+	var args []argument
+	for {
+		p.skipSpace()
+		if p.pos == len(p.input) {
+			return nil, p.errorf(p.pos, "missing ')'")
+		}
+		switch p.input[p.pos] {
+		case ')':
+			p.pos++
+			if len(args) == 0 {
+				return nil, p.errorf(p.pos-1, "missing arguments")
+			}
+			return args, nil
+		case ',', '/':
+			p.pos++
+			continue
+		}
+		start := p.pos
+		for p.pos < len(p.input) && !isSpace(p.input[p.pos]) && !strings.ContainsRune(",/)", rune(p.input[p.pos])) {
+			p.pos++
+		}
+		args = append(args, argument{text: p.input[start:p.pos], pos: start})
+	}
+}
+
+// channel parses a channel given as a number in the range 0-limit or as a
+// percentage, scaled to 0-255.
+func (p *colorParser) channel(a argument, limit float64) (uint8, error) {
+	text := a.text
+	if strings.HasSuffix(text, "%") {
+		text = strings.TrimSuffix(text, "%")
+		limit = 100
+	}
+	v, err := p.number(text, a.pos)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 || v > limit {
+		return 0, p.errorf(a.pos, "%s is out of range 0-%g", a.text, limit)
+	}
+	return toByte(v / limit), nil
+}
+
+func (p *colorParser) number(text string, pos int) (float64, error) {
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, p.errorf(pos, "invalid number %q", text)
+	}
+	return v, nil
+}
+
+func (p *colorParser) ident() string {
+	start := p.pos
+	for p.pos < len(p.input) && isAlpha(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *colorParser) skipSpace() {
+	for p.pos < len(p.input) && isSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+func isAlpha(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+func isHexDigit(b byte) bool {
+	return b >= '0' && b <= '9' || b >= 'a' && b <= 'f' || b >= 'A' && b <= 'F'
+}
+
+// toByte scales a value in the range 0-1 to 0-255.
+func toByte(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
+
+func hslToRGB(h, s, l float64) (r, g, b float64) {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		a := s * math.Min(l, 1-l)
+		return l - a*math.Max(-1, math.Min(k-3, math.Min(9-k, 1)))
+	}
+	return f(0), f(8), f(4)
+}
@@ -0,0 +1,100 @@
+package base16
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Color
+	}{
+		{"#abc", Color{0xaa, 0xbb, 0xcc, 0xff}},
+		{"#ABC", Color{0xaa, 0xbb, 0xcc, 0xff}},
+		{"#1d1f21", Color{0x1d, 0x1f, 0x21, 0xff}},
+		{"#1d1f2180", Color{0x1d, 0x1f, 0x21, 0x80}},
+		{"  #1d1f21  ", Color{0x1d, 0x1f, 0x21, 0xff}},
+		{"rgb(1, 2, 3)", Color{1, 2, 3, 0xff}},
+		{"rgb(1 2 3)", Color{1, 2, 3, 0xff}},
+		{"rgb(100%, 0%, 50%)", Color{0xff, 0, 0x80, 0xff}},
+		{"rgba(1, 2, 3, 0.5)", Color{1, 2, 3, 0x80}},
+		{"rgb(1 2 3 / 50%)", Color{1, 2, 3, 0x80}},
+		{"RGB(1,2,3)", Color{1, 2, 3, 0xff}},
+		{"hsl(0, 100%, 50%)", Color{0xff, 0, 0, 0xff}},
+		{"hsl(120deg 100% 25%)", Color{0, 0x80, 0, 0xff}},
+		{"hsl(-120, 100%, 50%)", Color{0, 0, 0xff, 0xff}},
+		{"hsla(240, 100%, 50%, 0)", Color{0, 0, 0xff, 0}},
+		{"red", Color{0xff, 0, 0, 0xff}},
+		{"RebeccaPurple", Color{0x66, 0x33, 0x99, 0xff}},
+		{"transparent", Color{0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		got, err := ParseColor(tt.in)
+		if err != nil {
+			t.Errorf("ParseColor(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseColor(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseColorErrors(t *testing.T) {
+	tests := []struct {
+		in  string
+		pos int
+		msg string
+	}{
+		{"", 0, "empty color"},
+		{"   ", 3, "empty color"},
+		{"abcdef", 0, "missing '#' before hex digits"},
+		{"#abcd", 0, "expecting 3, 6 or 8 hex digits, got 4"},
+		{"#", 0, "expecting 3, 6 or 8 hex digits, got 0"},
+		{"#12345g", 6, `invalid hex digit 'g'`},
+		{"#123 x", 5, `unexpected "x" after color`},
+		{"notacolor", 0, `unknown color name "notacolor"`},
+		{"rgb 1, 2, 3", 4, "expecting '('"},
+		{"rgb(1, 2, 3", 11, "missing ')'"},
+		{"rgb()", 4, "missing arguments"},
+		{"rgb(1, 2)", 4, "rgb() takes 3 or 4 arguments, got 2"},
+		{"rgb(1, 2, 256)", 10, "256 is out of range 0-255"},
+		{"rgb(1, x, 3)", 7, `invalid number "x"`},
+		{"rgba(1, 2, 3, 2)", 14, "2 is out of range 0-1"},
+		{"hsl(0, 100, 50%)", 7, "expecting a percentage"},
+		{"hsl(0, 100%, 150%)", 13, "150% is out of range 0%-100%"},
+		{"12", 0, "missing '#' before hex digits"},
+		{"%red", 0, "expecting '#', a color function or a color name"},
+	}
+	for _, tt := range tests {
+		_, err := ParseColor(tt.in)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("ParseColor(%q) error = %v, want a *ParseError", tt.in, err)
+			continue
+		}
+		if pe.Pos != tt.pos || pe.Msg != tt.msg {
+			t.Errorf("ParseColor(%q) error at %d %q, want at %d %q", tt.in, pe.Pos, pe.Msg, tt.pos, tt.msg)
+		}
+	}
+}
+
+func TestColorFormats(t *testing.T) {
+	c := Color{0x1d, 0x1f, 0xff, 0xff}
+	if got := c.String(); got != "#1d1fff" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := c.Hex(); got != "1d1fff" {
+		t.Errorf("Hex() = %q", got)
+	}
+	if got := c.Slash(); got != "1d/1f/ff" {
+		t.Errorf("Slash() = %q", got)
+	}
+	if r, g, b := c.Ints(); r != 29 || g != 31 || b != 255 {
+		t.Errorf("Ints() = %d, %d, %d", r, g, b)
+	}
+	if r, _, b := c.Floats(); r != 29.0/255 || b != 1 {
+		t.Errorf("Floats() = %g, _, %g", r, b)
+	}
+}
@@ -0,0 +1,33 @@
+package base16
+
+import "math"
+
+// cubeLevels are the channel values of xterm's 6x6x6 color cube.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// Xterm256 returns the color xterm uses for index i of its 256-color
+// palette, for i from 16 to 255. The first sixteen depend on the terminal's
+// theme and have no fixed color, so indexes outside that range are clamped
+// to it.
+func Xterm256(i int) Color {
+	i = min(max(i, 16), 255)
+	if i < 232 {
+		i -= 16
+		return Color{R: cubeLevels[i/36], G: cubeLevels[i/6%6], B: cubeLevels[i%6], A: 255}
+	}
+	v := uint8(8 + (i-232)*10)
+	return Color{R: v, G: v, B: v, A: 255}
+}
+
+// NearestXterm256 returns the index from 16 to 255 whose color is closest
+// to c in OKLab.
+func NearestXterm256(c Color) int {
+	lab := toOKLab(c)
+	best, bestDist := 16, math.Inf(1)
+	for i := 16; i < 256; i++ {
+		if d := lab.distance(toOKLab(Xterm256(i))); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
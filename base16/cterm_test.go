@@ -0,0 +1,48 @@
+package base16
+
+import "testing"
+
+func TestXterm256(t *testing.T) {
+	tests := []struct {
+		i    int
+		want string
+	}{
+		{16, "000000"},
+		{21, "0000ff"},
+		{67, "5f87af"},
+		{196, "ff0000"},
+		{231, "ffffff"},
+		{232, "080808"},
+		{244, "808080"},
+		{255, "eeeeee"},
+		{0, "000000"},   // clamped to 16
+		{-1, "000000"},  // clamped to 16
+		{256, "eeeeee"}, // clamped to 255
+	}
+	for _, tt := range tests {
+		if got := Xterm256(tt.i).Hex(); got != tt.want {
+			t.Errorf("Xterm256(%d) = %s, want %s", tt.i, got, tt.want)
+		}
+	}
+}
+
+func TestNearestXterm256(t *testing.T) {
+	for i := 16; i < 256; i++ {
+		if got := NearestXterm256(Xterm256(i)); got != i {
+			t.Errorf("NearestXterm256(%v) = %d, want %d", Xterm256(i), got, i)
+		}
+	}
+	tests := []struct {
+		c    string
+		want int
+	}{
+		{"#fe0101", 196},
+		{"#0a0a0a", 232},
+		{"#1d1f21", 234},
+	}
+	for _, tt := range tests {
+		if got := NearestXterm256(mustColor(t, tt.c)); got != tt.want {
+			t.Errorf("NearestXterm256(%s) = %d, want %d", tt.c, got, tt.want)
+		}
+	}
+}
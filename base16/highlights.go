@@ -0,0 +1,665 @@
+package base16
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// highlight is a neovim highlight group colored from the scheme's slots.
+// Attr is a comma separated list of attributes such as "bold,italic".
+type highlight struct {
+	group      string
+	fg, bg, sp Slot
+	attr       string
+}
+
+// NoSlot leaves a highlight color unset.
+const NoSlot Slot = -1
+
+type highlightSection struct {
+	name   string
+	groups []highlight
+}
+
+// guiOnlyBg lists groups whose background base16-vim sets in the GUI but
+// not in the terminal, unless overridden.
+var guiOnlyBg = map[string]bool{"WildMenu": true}
+
+// highlightSections are the groups defined by the neovim colorschemes, in
+// order.
+var highlightSections = []highlightSection{
+	{"Vim editor colors", []highlight{
+		{"Normal", Base05, Base00, NoSlot, ""},
+		{"Bold", NoSlot, NoSlot, NoSlot, "bold"},
+		{"Debug", Base08, NoSlot, NoSlot, ""},
+		{"Directory", Base0D, NoSlot, NoSlot, ""},
+		{"Error", Base00, Base08, NoSlot, ""},
+		{"ErrorMsg", Base08, Base00, NoSlot, ""},
+		{"Exception", Base08, NoSlot, NoSlot, ""},
+		{"FoldColumn", Base0C, Base01, NoSlot, ""},
+		{"Folded", Base03, Base01, NoSlot, ""},
+		{"IncSearch", Base01, Base09, NoSlot, "none"},
+		{"Italic", NoSlot, NoSlot, NoSlot, "none"},
+		{"Macro", Base08, NoSlot, NoSlot, ""},
+		{"MatchParen", NoSlot, Base03, NoSlot, ""},
+		{"ModeMsg", Base0B, NoSlot, NoSlot, ""},
+		{"MoreMsg", Base0B, NoSlot, NoSlot, ""},
+		{"Question", Base0D, NoSlot, NoSlot, ""},
+		{"Search", Base01, Base0A, NoSlot, ""},
+		{"Substitute", Base01, Base0A, NoSlot, "none"},
+		{"SpecialKey", Base03, NoSlot, NoSlot, ""},
+		{"TooLong", Base08, NoSlot, NoSlot, ""},
+		{"Underlined", Base08, NoSlot, NoSlot, ""},
+		{"Visual", NoSlot, Base02, NoSlot, ""},
+		{"VisualNOS", Base08, NoSlot, NoSlot, ""},
+		{"WarningMsg", Base08, NoSlot, NoSlot, ""},
+		{"WildMenu", Base08, Base0A, NoSlot, ""},
+		{"Title", Base0D, NoSlot, NoSlot, "none"},
+		{"Conceal", Base0D, Base00, NoSlot, ""},
+		{"Cursor", Base00, Base05, NoSlot, ""},
+		{"NonText", Base03, NoSlot, NoSlot, ""},
+		{"LineNr", Base03, Base01, NoSlot, ""},
+		{"SignColumn", Base03, Base01, NoSlot, ""},
+		{"StatusLine", Base04, Base02, NoSlot, "none"},
+		{"StatusLineNC", Base03, Base01, NoSlot, "none"},
+		{"VertSplit", Base02, Base02, NoSlot, "none"},
+		{"ColorColumn", NoSlot, Base01, NoSlot, "none"},
+		{"CursorColumn", NoSlot, Base01, NoSlot, "none"},
+		{"CursorLine", NoSlot, Base01, NoSlot, "none"},
+		{"CursorLineNr", Base04, Base01, NoSlot, ""},
+		{"QuickFixLine", NoSlot, Base01, NoSlot, "none"},
+		{"PMenu", Base05, Base01, NoSlot, "none"},
+		{"PMenuSel", Base01, Base05, NoSlot, ""},
+		{"TabLine", Base03, Base01, NoSlot, "none"},
+		{"TabLineFill", Base03, Base01, NoSlot, "none"},
+		{"TabLineSel", Base0B, Base01, NoSlot, "none"},
+	}},
+	{"Standard syntax highlighting", []highlight{
+		{"Boolean", Base09, NoSlot, NoSlot, ""},
+		{"Character", Base08, NoSlot, NoSlot, ""},
+		{"Comment", Base03, NoSlot, NoSlot, ""},
+		{"Conditional", Base0E, NoSlot, NoSlot, ""},
+		{"Constant", Base09, NoSlot, NoSlot, ""},
+		{"Define", Base0E, NoSlot, NoSlot, "none"},
+		{"Delimiter", Base0F, NoSlot, NoSlot, ""},
+		{"Float", Base09, NoSlot, NoSlot, ""},
+		{"Function", Base0D, NoSlot, NoSlot, ""},
+		{"Identifier", Base08, NoSlot, NoSlot, "none"},
+		{"Include", Base0D, NoSlot, NoSlot, ""},
+		{"Keyword", Base0E, NoSlot, NoSlot, ""},
+		{"Label", Base0A, NoSlot, NoSlot, ""},
+		{"Number", Base09, NoSlot, NoSlot, ""},
+		{"Operator", Base05, NoSlot, NoSlot, "none"},
+		{"PreProc", Base0A, NoSlot, NoSlot, ""},
+		{"Repeat", Base0A, NoSlot, NoSlot, ""},
+		{"Special", Base0C, NoSlot, NoSlot, ""},
+		{"SpecialChar", Base0F, NoSlot, NoSlot, ""},
+		{"Statement", Base08, NoSlot, NoSlot, ""},
+		{"StorageClass", Base0A, NoSlot, NoSlot, ""},
+		{"String", Base0B, NoSlot, NoSlot, ""},
+		{"Structure", Base0E, NoSlot, NoSlot, ""},
+		{"Tag", Base0A, NoSlot, NoSlot, ""},
+		{"Todo", Base0A, Base01, NoSlot, ""},
+		{"Type", Base0A, NoSlot, NoSlot, "none"},
+		{"Typedef", Base0A, NoSlot, NoSlot, ""},
+	}},
+	{"C highlighting", []highlight{
+		{"cOperator", Base0C, NoSlot, NoSlot, ""},
+		{"cPreCondit", Base0E, NoSlot, NoSlot, ""},
+	}},
+	{"C# highlighting", []highlight{
+		{"csClass", Base0A, NoSlot, NoSlot, ""},
+		{"csAttribute", Base0A, NoSlot, NoSlot, ""},
+		{"csModifier", Base0E, NoSlot, NoSlot, ""},
+		{"csType", Base08, NoSlot, NoSlot, ""},
+		{"csUnspecifiedStatement", Base0D, NoSlot, NoSlot, ""},
+		{"csContextualStatement", Base0E, NoSlot, NoSlot, ""},
+		{"csNewDecleration", Base08, NoSlot, NoSlot, ""},
+	}},
+	{"CSS highlighting", []highlight{
+		{"cssBraces", Base05, NoSlot, NoSlot, ""},
+		{"cssClassName", Base0E, NoSlot, NoSlot, ""},
+		{"cssColor", Base0C, NoSlot, NoSlot, ""},
+	}},
+	{"Diff highlighting", []highlight{
+		{"DiffAdd", Base0B, Base01, NoSlot, ""},
+		{"DiffChange", Base03, Base01, NoSlot, ""},
+		{"DiffDelete", Base08, Base01, NoSlot, ""},
+		{"DiffText", Base0D, Base01, NoSlot, ""},
+		{"DiffAdded", Base0B, Base00, NoSlot, ""},
+		{"DiffFile", Base08, Base00, NoSlot, ""},
+		{"DiffNewFile", Base0B, Base00, NoSlot, ""},
+		{"DiffLine", Base0D, Base00, NoSlot, ""},
+		{"DiffRemoved", Base08, Base00, NoSlot, ""},
+	}},
+	{"Git highlighting", []highlight{
+		{"gitcommitOverflow", Base08, NoSlot, NoSlot, ""},
+		{"gitcommitSummary", Base0B, NoSlot, NoSlot, ""},
+		{"gitcommitComment", Base03, NoSlot, NoSlot, ""},
+		{"gitcommitUntracked", Base03, NoSlot, NoSlot, ""},
+		{"gitcommitDiscarded", Base03, NoSlot, NoSlot, ""},
+		{"gitcommitSelected", Base03, NoSlot, NoSlot, ""},
+		{"gitcommitHeader", Base0E, NoSlot, NoSlot, ""},
+		{"gitcommitSelectedType", Base0D, NoSlot, NoSlot, ""},
+		{"gitcommitUnmergedType", Base0D, NoSlot, NoSlot, ""},
+		{"gitcommitDiscardedType", Base0D, NoSlot, NoSlot, ""},
+		{"gitcommitBranch", Base09, NoSlot, NoSlot, "bold"},
+		{"gitcommitUntrackedFile", Base0A, NoSlot, NoSlot, ""},
+		{"gitcommitUnmergedFile", Base08, NoSlot, NoSlot, "bold"},
+		{"gitcommitDiscardedFile", Base08, NoSlot, NoSlot, "bold"},
+		{"gitcommitSelectedFile", Base0B, NoSlot, NoSlot, "bold"},
+	}},
+	{"GitGutter highlighting", []highlight{
+		{"GitGutterAdd", Base0B, Base01, NoSlot, ""},
+		{"GitGutterChange", Base0D, Base01, NoSlot, ""},
+		{"GitGutterDelete", Base08, Base01, NoSlot, ""},
+		{"GitGutterChangeDelete", Base0E, Base01, NoSlot, ""},
+	}},
+	{"HTML highlighting", []highlight{
+		{"htmlBold", Base0A, NoSlot, NoSlot, ""},
+		{"htmlItalic", Base0E, NoSlot, NoSlot, ""},
+		{"htmlEndTag", Base05, NoSlot, NoSlot, ""},
+		{"htmlTag", Base05, NoSlot, NoSlot, ""},
+	}},
+	{"JavaScript highlighting", []highlight{
+		{"javaScript", Base05, NoSlot, NoSlot, ""},
+		{"javaScriptBraces", Base05, NoSlot, NoSlot, ""},
+		{"javaScriptNumber", Base09, NoSlot, NoSlot, ""},
+	}},
+	{"pangloss/vim-javascript highlighting", []highlight{
+		{"jsOperator", Base0D, NoSlot, NoSlot, ""},
+		{"jsStatement", Base0E, NoSlot, NoSlot, ""},
+		{"jsReturn", Base0E, NoSlot, NoSlot, ""},
+		{"jsThis", Base08, NoSlot, NoSlot, ""},
+		{"jsClassDefinition", Base0A, NoSlot, NoSlot, ""},
+		{"jsFunction", Base0E, NoSlot, NoSlot, ""},
+		{"jsFuncName", Base0D, NoSlot, NoSlot, ""},
+		{"jsFuncCall", Base0D, NoSlot, NoSlot, ""},
+		{"jsClassFuncName", Base0D, NoSlot, NoSlot, ""},
+		{"jsClassMethodType", Base0E, NoSlot, NoSlot, ""},
+		{"jsRegexpString", Base0C, NoSlot, NoSlot, ""},
+		{"jsGlobalObjects", Base0A, NoSlot, NoSlot, ""},
+		{"jsGlobalNodeObjects", Base0A, NoSlot, NoSlot, ""},
+		{"jsExceptions", Base0A, NoSlot, NoSlot, ""},
+		{"jsBuiltins", Base0A, NoSlot, NoSlot, ""},
+	}},
+	{"Mail highlighting", []highlight{
+		{"mailQuoted1", Base0A, NoSlot, NoSlot, ""},
+		{"mailQuoted2", Base0B, NoSlot, NoSlot, ""},
+		{"mailQuoted3", Base0E, NoSlot, NoSlot, ""},
+		{"mailQuoted4", Base0C, NoSlot, NoSlot, ""},
+		{"mailQuoted5", Base0D, NoSlot, NoSlot, ""},
+		{"mailQuoted6", Base0A, NoSlot, NoSlot, ""},
+		{"mailURL", Base0D, NoSlot, NoSlot, ""},
+		{"mailEmail", Base0D, NoSlot, NoSlot, ""},
+	}},
+	{"Markdown highlighting", []highlight{
+		{"markdownCode", Base0B, NoSlot, NoSlot, ""},
+		{"markdownError", Base05, Base00, NoSlot, ""},
+		{"markdownCodeBlock", Base0B, NoSlot, NoSlot, ""},
+		{"markdownHeadingDelimiter", Base0D, NoSlot, NoSlot, ""},
+	}},
+	{"NERDTree highlighting", []highlight{
+		{"NERDTreeDirSlash", Base0D, NoSlot, NoSlot, ""},
+		{"NERDTreeExecFile", Base05, NoSlot, NoSlot, ""},
+	}},
+	{"PHP highlighting", []highlight{
+		{"phpMemberSelector", Base05, NoSlot, NoSlot, ""},
+		{"phpComparison", Base05, NoSlot, NoSlot, ""},
+		{"phpParent", Base05, NoSlot, NoSlot, ""},
+		{"phpMethodsVar", Base0C, NoSlot, NoSlot, ""},
+	}},
+	{"Python highlighting", []highlight{
+		{"pythonOperator", Base0E, NoSlot, NoSlot, ""},
+		{"pythonRepeat", Base0E, NoSlot, NoSlot, ""},
+		{"pythonInclude", Base0E, NoSlot, NoSlot, ""},
+		{"pythonStatement", Base0E, NoSlot, NoSlot, ""},
+	}},
+	{"Ruby highlighting", []highlight{
+		{"rubyAttribute", Base0D, NoSlot, NoSlot, ""},
+		{"rubyConstant", Base0A, NoSlot, NoSlot, ""},
+		{"rubyInterpolationDelimiter", Base0F, NoSlot, NoSlot, ""},
+		{"rubyRegexp", Base0C, NoSlot, NoSlot, ""},
+		{"rubySymbol", Base0B, NoSlot, NoSlot, ""},
+		{"rubyStringDelimiter", Base0B, NoSlot, NoSlot, ""},
+	}},
+	{"SASS highlighting", []highlight{
+		{"sassidChar", Base08, NoSlot, NoSlot, ""},
+		{"sassClassChar", Base09, NoSlot, NoSlot, ""},
+		{"sassInclude", Base0E, NoSlot, NoSlot, ""},
+		{"sassMixing", Base0E, NoSlot, NoSlot, ""},
+		{"sassMixinName", Base0D, NoSlot, NoSlot, ""},
+	}},
+	{"Signify highlighting", []highlight{
+		{"SignifySignAdd", Base0B, Base01, NoSlot, ""},
+		{"SignifySignChange", Base0D, Base01, NoSlot, ""},
+		{"SignifySignDelete", Base08, Base01, NoSlot, ""},
+	}},
+	{"Spelling highlighting", []highlight{
+		{"SpellBad", NoSlot, NoSlot, Base08, "undercurl"},
+		{"SpellLocal", NoSlot, NoSlot, Base0C, "undercurl"},
+		{"SpellCap", NoSlot, NoSlot, Base0D, "undercurl"},
+		{"SpellRare", NoSlot, NoSlot, Base0E, "undercurl"},
+	}},
+	{"Startify highlighting", []highlight{
+		{"StartifyBracket", Base03, NoSlot, NoSlot, ""},
+		{"StartifyFile", Base07, NoSlot, NoSlot, ""},
+		{"StartifyFooter", Base03, NoSlot, NoSlot, ""},
+		{"StartifyHeader", Base0B, NoSlot, NoSlot, ""},
+		{"StartifyNumber", Base09, NoSlot, NoSlot, ""},
+		{"StartifyPath", Base03, NoSlot, NoSlot, ""},
+		{"StartifySection", Base0E, NoSlot, NoSlot, ""},
+		{"StartifySelect", Base0C, NoSlot, NoSlot, ""},
+		{"StartifySlash", Base03, NoSlot, NoSlot, ""},
+		{"StartifySpecial", Base03, NoSlot, NoSlot, ""},
+	}},
+	{"Java highlighting", []highlight{
+		{"javaOperator", Base0D, NoSlot, NoSlot, ""},
+	}},
+}
+
+// neovimHighlightSections use groups only neovim knows: tree-sitter
+// captures, LSP semantic tokens and diagnostics.
+var neovimHighlightSections = []highlightSection{
+	{"Tree-sitter highlighting", []highlight{
+		{"@variable", Base05, NoSlot, NoSlot, ""},
+		{"@variable.builtin", Base08, NoSlot, NoSlot, ""},
+		{"@variable.parameter", Base08, NoSlot, NoSlot, ""},
+		{"@variable.member", Base08, NoSlot, NoSlot, ""},
+		{"@constant", Base09, NoSlot, NoSlot, ""},
+		{"@constant.builtin", Base09, NoSlot, NoSlot, ""},
+		{"@constant.macro", Base08, NoSlot, NoSlot, ""},
+		{"@module", Base05, NoSlot, NoSlot, ""},
+		{"@label", Base0A, NoSlot, NoSlot, ""},
+		{"@string", Base0B, NoSlot, NoSlot, ""},
+		{"@string.regexp", Base0C, NoSlot, NoSlot, ""},
+		{"@string.escape", Base0C, NoSlot, NoSlot, ""},
+		{"@string.special", Base0F, NoSlot, NoSlot, ""},
+		{"@string.special.url", Base0D, NoSlot, NoSlot, "underline"},
+		{"@character", Base08, NoSlot, NoSlot, ""},
+		{"@character.special", Base0F, NoSlot, NoSlot, ""},
+		{"@boolean", Base09, NoSlot, NoSlot, ""},
+		{"@number", Base09, NoSlot, NoSlot, ""},
+		{"@number.float", Base09, NoSlot, NoSlot, ""},
+		{"@type", Base0A, NoSlot, NoSlot, ""},
+		{"@type.builtin", Base0A, NoSlot, NoSlot, ""},
+		{"@type.definition", Base0A, NoSlot, NoSlot, ""},
+		{"@attribute", Base0A, NoSlot, NoSlot, ""},
+		{"@property", Base08, NoSlot, NoSlot, ""},
+		{"@function", Base0D, NoSlot, NoSlot, ""},
+		{"@function.builtin", Base0D, NoSlot, NoSlot, ""},
+		{"@function.call", Base0D, NoSlot, NoSlot, ""},
+		{"@function.macro", Base08, NoSlot, NoSlot, ""},
+		{"@function.method", Base0D, NoSlot, NoSlot, ""},
+		{"@function.method.call", Base0D, NoSlot, NoSlot, ""},
+		{"@constructor", Base0C, NoSlot, NoSlot, ""},
+		{"@operator", Base05, NoSlot, NoSlot, ""},
+		{"@keyword", Base0E, NoSlot, NoSlot, ""},
+		{"@keyword.function", Base0E, NoSlot, NoSlot, ""},
+		{"@keyword.operator", Base0E, NoSlot, NoSlot, ""},
+		{"@keyword.return", Base0E, NoSlot, NoSlot, ""},
+		{"@keyword.conditional", Base0E, NoSlot, NoSlot, ""},
+		{"@keyword.repeat", Base0A, NoSlot, NoSlot, ""},
+		{"@keyword.import", Base0D, NoSlot, NoSlot, ""},
+		{"@keyword.exception", Base08, NoSlot, NoSlot, ""},
+		{"@punctuation.delimiter", Base0F, NoSlot, NoSlot, ""},
+		{"@punctuation.bracket", Base05, NoSlot, NoSlot, ""},
+		{"@punctuation.special", Base0F, NoSlot, NoSlot, ""},
+		{"@comment", Base03, NoSlot, NoSlot, ""},
+		{"@comment.todo", Base0A, Base01, NoSlot, ""},
+		{"@comment.error", Base08, NoSlot, NoSlot, ""},
+		{"@comment.warning", Base0A, NoSlot, NoSlot, ""},
+		{"@comment.note", Base0D, NoSlot, NoSlot, ""},
+		{"@markup.heading", Base0D, NoSlot, NoSlot, "bold"},
+		{"@markup.strong", NoSlot, NoSlot, NoSlot, "bold"},
+		{"@markup.italic", NoSlot, NoSlot, NoSlot, "italic"},
+		{"@markup.strikethrough", NoSlot, NoSlot, NoSlot, "strikethrough"},
+		{"@markup.underline", NoSlot, NoSlot, NoSlot, "underline"},
+		{"@markup.link", Base0D, NoSlot, NoSlot, ""},
+		{"@markup.link.url", Base0D, NoSlot, NoSlot, "underline"},
+		{"@markup.raw", Base0B, NoSlot, NoSlot, ""},
+		{"@markup.list", Base08, NoSlot, NoSlot, ""},
+		{"@markup.quote", Base03, NoSlot, NoSlot, ""},
+		{"@diff.plus", Base0B, NoSlot, NoSlot, ""},
+		{"@diff.minus", Base08, NoSlot, NoSlot, ""},
+		{"@diff.delta", Base0D, NoSlot, NoSlot, ""},
+		{"@tag", Base0A, NoSlot, NoSlot, ""},
+		{"@tag.attribute", Base0D, NoSlot, NoSlot, ""},
+		{"@tag.delimiter", Base05, NoSlot, NoSlot, ""},
+	}},
+	{"LSP highlighting", []highlight{
+		{"@lsp.type.class", Base0A, NoSlot, NoSlot, ""},
+		{"@lsp.type.decorator", Base0A, NoSlot, NoSlot, ""},
+		{"@lsp.type.enum", Base0A, NoSlot, NoSlot, ""},
+		{"@lsp.type.enumMember", Base09, NoSlot, NoSlot, ""},
+		{"@lsp.type.function", Base0D, NoSlot, NoSlot, ""},
+		{"@lsp.type.interface", Base0A, NoSlot, NoSlot, ""},
+		{"@lsp.type.keyword", Base0E, NoSlot, NoSlot, ""},
+		{"@lsp.type.macro", Base08, NoSlot, NoSlot, ""},
+		{"@lsp.type.method", Base0D, NoSlot, NoSlot, ""},
+		{"@lsp.type.namespace", Base05, NoSlot, NoSlot, ""},
+		{"@lsp.type.parameter", Base08, NoSlot, NoSlot, ""},
+		{"@lsp.type.property", Base08, NoSlot, NoSlot, ""},
+		{"@lsp.type.struct", Base0A, NoSlot, NoSlot, ""},
+		{"@lsp.type.type", Base0A, NoSlot, NoSlot, ""},
+		{"@lsp.type.typeParameter", Base0A, NoSlot, NoSlot, ""},
+		{"@lsp.type.variable", Base05, NoSlot, NoSlot, ""},
+		{"@lsp.typemod.variable.defaultLibrary", Base08, NoSlot, NoSlot, ""},
+		{"@lsp.mod.deprecated", NoSlot, NoSlot, NoSlot, "strikethrough"},
+		{"LspReferenceText", NoSlot, Base02, NoSlot, ""},
+		{"LspReferenceRead", NoSlot, Base02, NoSlot, ""},
+		{"LspReferenceWrite", NoSlot, Base02, NoSlot, ""},
+		{"LspSignatureActiveParameter", Base09, NoSlot, NoSlot, "bold"},
+		{"LspInlayHint", Base03, Base01, NoSlot, ""},
+	}},
+	{"Diagnostic highlighting", []highlight{
+		{"DiagnosticError", Base08, NoSlot, NoSlot, ""},
+		{"DiagnosticWarn", Base0E, NoSlot, NoSlot, ""},
+		{"DiagnosticInfo", Base0C, NoSlot, NoSlot, ""},
+		{"DiagnosticHint", Base0D, NoSlot, NoSlot, ""},
+		{"DiagnosticOk", Base0B, NoSlot, NoSlot, ""},
+		{"DiagnosticFloatingError", Base08, Base01, NoSlot, ""},
+		{"DiagnosticFloatingWarn", Base0E, Base01, NoSlot, ""},
+		{"DiagnosticFloatingInfo", Base0C, Base01, NoSlot, ""},
+		{"DiagnosticFloatingHint", Base0D, Base01, NoSlot, ""},
+		{"DiagnosticFloatingOk", Base0B, Base01, NoSlot, ""},
+		{"DiagnosticSignError", Base08, Base01, NoSlot, ""},
+		{"DiagnosticSignWarn", Base0E, Base01, NoSlot, ""},
+		{"DiagnosticSignInfo", Base0C, Base01, NoSlot, ""},
+		{"DiagnosticSignHint", Base0D, Base01, NoSlot, ""},
+		{"DiagnosticSignOk", Base0B, Base01, NoSlot, ""},
+		{"DiagnosticVirtualTextError", Base08, NoSlot, NoSlot, ""},
+		{"DiagnosticVirtualTextWarn", Base0E, NoSlot, NoSlot, ""},
+		{"DiagnosticVirtualTextInfo", Base0C, NoSlot, NoSlot, ""},
+		{"DiagnosticVirtualTextHint", Base0D, NoSlot, NoSlot, ""},
+		{"DiagnosticVirtualTextOk", Base0B, NoSlot, NoSlot, ""},
+		{"DiagnosticUnderlineError", NoSlot, NoSlot, Base08, "undercurl"},
+		{"DiagnosticUnderlineWarn", NoSlot, NoSlot, Base0E, "undercurl"},
+		{"DiagnosticUnderlineInfo", NoSlot, NoSlot, Base0C, "undercurl"},
+		{"DiagnosticUnderlineHint", NoSlot, NoSlot, Base0D, "undercurl"},
+		{"DiagnosticUnderlineOk", NoSlot, NoSlot, Base0B, "undercurl"},
+		{"DiagnosticDeprecated", NoSlot, NoSlot, NoSlot, "strikethrough"},
+		{"DiagnosticUnnecessary", Base03, NoSlot, NoSlot, ""},
+	}},
+}
+
+// pluginPacks are optional sections for neovim plugins, selected by name
+// with NeovimOptions.Plugins.
+var pluginPacks = map[string]highlightSection{
+	"bufferline": {"bufferline highlighting", []highlight{
+		{"BufferLineFill", NoSlot, Base01, NoSlot, ""},
+		{"BufferLineBackground", Base03, Base01, NoSlot, ""},
+		{"BufferLineBufferVisible", Base04, Base01, NoSlot, ""},
+		{"BufferLineBufferSelected", Base05, Base00, NoSlot, "bold"},
+		{"BufferLineTab", Base03, Base01, NoSlot, ""},
+		{"BufferLineTabSelected", Base05, Base00, NoSlot, ""},
+		{"BufferLineTabClose", Base08, Base01, NoSlot, ""},
+		{"BufferLineIndicatorSelected", Base0D, Base00, NoSlot, ""},
+		{"BufferLineSeparator", Base01, Base01, NoSlot, ""},
+		{"BufferLineSeparatorSelected", Base01, Base00, NoSlot, ""},
+		{"BufferLineModified", Base0B, Base01, NoSlot, ""},
+		{"BufferLineModifiedSelected", Base0B, Base00, NoSlot, ""},
+		{"BufferLineCloseButtonSelected", Base08, Base00, NoSlot, ""},
+	}},
+	"gitsigns": {"gitsigns highlighting", []highlight{
+		{"GitSignsAdd", Base0B, Base01, NoSlot, ""},
+		{"GitSignsChange", Base0D, Base01, NoSlot, ""},
+		{"GitSignsDelete", Base08, Base01, NoSlot, ""},
+		{"GitSignsChangedelete", Base0E, Base01, NoSlot, ""},
+		{"GitSignsAddNr", Base0B, Base01, NoSlot, ""},
+		{"GitSignsChangeNr", Base0D, Base01, NoSlot, ""},
+		{"GitSignsDeleteNr", Base08, Base01, NoSlot, ""},
+		{"GitSignsCurrentLineBlame", Base03, NoSlot, NoSlot, ""},
+	}},
+	"indent-blankline": {"indent-blankline highlighting", []highlight{
+		{"IblIndent", Base02, NoSlot, NoSlot, ""},
+		{"IblWhitespace", Base02, NoSlot, NoSlot, ""},
+		{"IblScope", Base03, NoSlot, NoSlot, ""},
+		{"IndentBlanklineChar", Base02, NoSlot, NoSlot, ""},
+		{"IndentBlanklineSpaceChar", Base02, NoSlot, NoSlot, ""},
+		{"IndentBlanklineContextChar", Base03, NoSlot, NoSlot, ""},
+	}},
+	"lualine": {"lualine highlighting", []highlight{
+		{"lualine_a_normal", Base01, Base0D, NoSlot, "bold"},
+		{"lualine_b_normal", Base05, Base02, NoSlot, ""},
+		{"lualine_c_normal", Base04, Base01, NoSlot, ""},
+		{"lualine_a_insert", Base01, Base0B, NoSlot, "bold"},
+		{"lualine_b_insert", Base05, Base02, NoSlot, ""},
+		{"lualine_c_insert", Base04, Base01, NoSlot, ""},
+		{"lualine_a_visual", Base01, Base0E, NoSlot, "bold"},
+		{"lualine_b_visual", Base05, Base02, NoSlot, ""},
+		{"lualine_c_visual", Base04, Base01, NoSlot, ""},
+		{"lualine_a_replace", Base01, Base08, NoSlot, "bold"},
+		{"lualine_b_replace", Base05, Base02, NoSlot, ""},
+		{"lualine_c_replace", Base04, Base01, NoSlot, ""},
+		{"lualine_a_command", Base01, Base0A, NoSlot, "bold"},
+		{"lualine_b_command", Base05, Base02, NoSlot, ""},
+		{"lualine_c_command", Base04, Base01, NoSlot, ""},
+		{"lualine_a_inactive", Base03, Base01, NoSlot, "bold"},
+		{"lualine_b_inactive", Base03, Base01, NoSlot, ""},
+		{"lualine_c_inactive", Base03, Base01, NoSlot, ""},
+	}},
+	"nvim-cmp": {"nvim-cmp highlighting", []highlight{
+		{"CmpItemAbbr", Base05, NoSlot, NoSlot, ""},
+		{"CmpItemAbbrDeprecated", Base03, NoSlot, NoSlot, "strikethrough"},
+		{"CmpItemAbbrMatch", Base0D, NoSlot, NoSlot, "bold"},
+		{"CmpItemAbbrMatchFuzzy", Base0D, NoSlot, NoSlot, "bold"},
+		{"CmpItemKind", Base0E, NoSlot, NoSlot, ""},
+		{"CmpItemKindClass", Base0A, NoSlot, NoSlot, ""},
+		{"CmpItemKindConstant", Base09, NoSlot, NoSlot, ""},
+		{"CmpItemKindField", Base08, NoSlot, NoSlot, ""},
+		{"CmpItemKindFunction", Base0D, NoSlot, NoSlot, ""},
+		{"CmpItemKindKeyword", Base0E, NoSlot, NoSlot, ""},
+		{"CmpItemKindMethod", Base0D, NoSlot, NoSlot, ""},
+		{"CmpItemKindModule", Base0D, NoSlot, NoSlot, ""},
+		{"CmpItemKindSnippet", Base0C, NoSlot, NoSlot, ""},
+		{"CmpItemKindText", Base05, NoSlot, NoSlot, ""},
+		{"CmpItemKindVariable", Base08, NoSlot, NoSlot, ""},
+		{"CmpItemMenu", Base03, NoSlot, NoSlot, ""},
+	}},
+	"nvim-tree": {"nvim-tree highlighting", []highlight{
+		{"NvimTreeNormal", Base05, NoSlot, NoSlot, ""},
+		{"NvimTreeRootFolder", Base0E, NoSlot, NoSlot, "bold"},
+		{"NvimTreeFolderName", Base0D, NoSlot, NoSlot, ""},
+		{"NvimTreeFolderIcon", Base0D, NoSlot, NoSlot, ""},
+		{"NvimTreeOpenedFolderName", Base0D, NoSlot, NoSlot, "bold"},
+		{"NvimTreeEmptyFolderName", Base03, NoSlot, NoSlot, ""},
+		{"NvimTreeIndentMarker", Base02, NoSlot, NoSlot, ""},
+		{"NvimTreeExecFile", Base0B, NoSlot, NoSlot, ""},
+		{"NvimTreeSpecialFile", Base0C, NoSlot, NoSlot, "underline"},
+		{"NvimTreeImageFile", Base0E, NoSlot, NoSlot, ""},
+		{"NvimTreeSymlink", Base0C, NoSlot, NoSlot, ""},
+		{"NvimTreeGitDirty", Base08, NoSlot, NoSlot, ""},
+		{"NvimTreeGitNew", Base0B, NoSlot, NoSlot, ""},
+		{"NvimTreeGitDeleted", Base08, NoSlot, NoSlot, ""},
+		{"NvimTreeGitStaged", Base0A, NoSlot, NoSlot, ""},
+		{"NvimTreeWinSeparator", Base02, NoSlot, NoSlot, ""},
+	}},
+	"telescope": {"Telescope highlighting", []highlight{
+		{"TelescopeNormal", Base05, NoSlot, NoSlot, ""},
+		{"TelescopeBorder", Base03, NoSlot, NoSlot, ""},
+		{"TelescopePromptBorder", Base03, NoSlot, NoSlot, ""},
+		{"TelescopeResultsBorder", Base03, NoSlot, NoSlot, ""},
+		{"TelescopePreviewBorder", Base03, NoSlot, NoSlot, ""},
+		{"TelescopePromptTitle", Base0D, NoSlot, NoSlot, "bold"},
+		{"TelescopeResultsTitle", Base0D, NoSlot, NoSlot, "bold"},
+		{"TelescopePreviewTitle", Base0D, NoSlot, NoSlot, "bold"},
+		{"TelescopePromptPrefix", Base08, NoSlot, NoSlot, ""},
+		{"TelescopeSelection", Base05, Base02, NoSlot, ""},
+		{"TelescopeSelectionCaret", Base08, Base02, NoSlot, ""},
+		{"TelescopeMatching", Base0A, NoSlot, NoSlot, "bold"},
+		{"TelescopeMultiSelection", Base0E, NoSlot, NoSlot, ""},
+	}},
+	"which-key": {"which-key highlighting", []highlight{
+		{"WhichKey", Base0D, NoSlot, NoSlot, ""},
+		{"WhichKeyGroup", Base0E, NoSlot, NoSlot, ""},
+		{"WhichKeyDesc", Base05, NoSlot, NoSlot, ""},
+		{"WhichKeySeparator", Base03, NoSlot, NoSlot, ""},
+		{"WhichKeyValue", Base03, NoSlot, NoSlot, ""},
+		{"WhichKeyFloat", NoSlot, Base01, NoSlot, ""},
+		{"WhichKeyBorder", Base03, NoSlot, NoSlot, ""},
+	}},
+}
+
+// PluginNames lists the plugin packs NeovimOptions.Plugins accepts.
+func PluginNames() []string {
+	var names []string
+	for name := range pluginPacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NeovimOptions selects what the neovim colorschemes define beyond the
+// standard groups.
+type NeovimOptions struct {
+	Name      string   // colorscheme name, as given to :colorscheme; default base16-<slug>
+	Plugins   []string // plugin packs to append, from PluginNames
+	Overrides []HighlightOverride
+	Cterm256  bool // use the nearest xterm-256 index for each slot rather than base16-shell's
+}
+
+// NeovimVars returns TemplateVars plus the highlight groups the neovim
+// templates define, as a "highlights" list of sections. Sections marked
+// neovim use groups Vim does not have:
+//
+//	{{#highlights}}
+//	" {{section}} {{#neovim}}(neovim only){{/neovim}}
+//	{{#groups}}
+//	{{group}} {{fg}} {{bg}} {{sp}} {{attr}}
+//	{{/groups}}
+//	{{/highlights}}
+//
+// fg, bg and sp name a slot by its digits ("05" for base05) and are empty
+// when the group leaves that color unset; ctermbg is the background for
+// terminal Vim, which a few groups leave unset. pad holds the spaces that
+// align what follows the group name within its section.
+//
+// The plugin packs in opts follow the standard sections, and its overrides
+// are merged into the groups they name, in order. Overrides for groups not
+// defined are added in final sections, one for Vim and one for the
+// neovim-only @ groups.
+//
+// colors-name holds opts.Name escaped for a double-quoted Vim or Lua
+// string. With opts.Cterm256, "cterm256" is set and base00-cterm through
+// base0F-cterm hold each slot's nearest xterm-256 index.
+func NeovimVars(s Scheme, opts NeovimOptions) (map[string]any, error) {
+	type section struct {
+		highlightSection
+		neovim bool
+	}
+	var sections []section
+	add := func(sec highlightSection, neovim bool) {
+		groups := append([]highlight(nil), sec.groups...)
+		sections = append(sections, section{highlightSection{sec.name, groups}, neovim})
+	}
+	for _, sec := range highlightSections {
+		add(sec, false)
+	}
+	for _, sec := range neovimHighlightSections {
+		add(sec, true)
+	}
+
+	seen := map[string]bool{}
+	for _, name := range opts.Plugins {
+		sec, ok := pluginPacks[name]
+		if !ok {
+			return nil, fmt.Errorf("no plugin pack %q; choose from %s", name, strings.Join(PluginNames(), ", "))
+		}
+		if !seen[name] {
+			add(sec, true)
+			seen[name] = true
+		}
+	}
+
+	// Added groups starting with @ only exist in neovim, so they get a
+	// section of their own and the rest still reach Vim.
+	extra := []section{
+		{highlightSection: highlightSection{name: "User overrides"}},
+		{highlightSection: highlightSection{name: "User overrides (neovim)"}, neovim: true},
+	}
+	bgSet := map[string]bool{}
+	for _, o := range opts.Overrides {
+		if o.Bg != nil {
+			bgSet[o.Group] = true
+		}
+		found := false
+		for i := range sections {
+			for j := range sections[i].groups {
+				if sections[i].groups[j].group == o.Group {
+					o.apply(&sections[i].groups[j])
+					found = true
+				}
+			}
+		}
+		sec := &extra[0]
+		if strings.HasPrefix(o.Group, "@") {
+			sec = &extra[1]
+		}
+		for j := range sec.groups {
+			if sec.groups[j].group == o.Group {
+				o.apply(&sec.groups[j])
+				found = true
+			}
+		}
+		if !found {
+			h := highlight{group: o.Group, fg: NoSlot, bg: NoSlot, sp: NoSlot}
+			o.apply(&h)
+			sec.groups = append(sec.groups, h)
+		}
+	}
+	for _, sec := range extra {
+		if len(sec.groups) > 0 {
+			sections = append(sections, sec)
+		}
+	}
+
+	var list []map[string]any
+	for _, sec := range sections {
+		width := 0
+		for _, h := range sec.groups {
+			width = max(width, len(h.group))
+		}
+		var groups []map[string]any
+		for _, h := range sec.groups {
+			ctermbg := slotDigits(h.bg)
+			if guiOnlyBg[h.group] && !bgSet[h.group] {
+				ctermbg = ""
+			}
+			groups = append(groups, map[string]any{
+				"group":   h.group,
+				"pad":     strings.Repeat(" ", width-len(h.group)+2),
+				"fg":      slotDigits(h.fg),
+				"bg":      slotDigits(h.bg),
+				"ctermbg": ctermbg,
+				"sp":      slotDigits(h.sp),
+				"attr":    h.attr,
+			})
+		}
+		list = append(list, map[string]any{"section": sec.name, "neovim": sec.neovim, "groups": groups})
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = "base16-" + s.Slug
+	}
+	vars := TemplateVars(s)
+	vars["colors-name"] = escapeString(name)
+	vars["highlights"] = list
+	if opts.Cterm256 {
+		vars["cterm256"] = true
+		for _, slot := range s.Slots()[:NumBase16Slots] {
+			vars[slot.String()+"-cterm"] = fmt.Sprintf("%02d", NearestXterm256(s.Colors[slot]))
+		}
+	}
+	return vars, nil
+}
+
+func slotDigits(s Slot) string {
+	if s == NoSlot {
+		return ""
+	}
+	return strings.TrimPrefix(s.String(), "base")
+}
@@ -0,0 +1,325 @@
+package base16
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RafaelPiloto10/base16-terminal-sexy/internal/yaml"
+)
+
+// Source is a color of a terminal.sexy export: one of the sixteen ANSI
+// colors, the foreground or the background.
+type Source int
+
+const (
+	SourceForeground Source = 16 + iota
+	SourceBackground
+)
+
+// ParseSource parses "color0" through "color15", "foreground" or
+// "background".
+func ParseSource(s string) (Source, error) {
+	switch s {
+	case "foreground":
+		return SourceForeground, nil
+	case "background":
+		return SourceBackground, nil
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(s, "color")); err == nil && strings.HasPrefix(s, "color") && n >= 0 && n < 16 {
+		return Source(n), nil
+	}
+	return 0, fmt.Errorf("unknown color %q; expecting color0-color15, foreground or background", s)
+}
+
+func (s Source) String() string {
+	switch s {
+	case SourceForeground:
+		return "foreground"
+	case SourceBackground:
+		return "background"
+	case sourceDerived:
+		return "derived"
+	}
+	return fmt.Sprintf("color%d", int(s))
+}
+
+func (s Source) resolve(t Terminal) Color {
+	switch s {
+	case SourceForeground:
+		return t.Foreground
+	case SourceBackground:
+		return t.Background
+	}
+	return t.Colors[s]
+}
+
+// Ref names a color either by base16 slot ("base02") or by terminal.sexy
+// color ("color8", "foreground").
+type Ref struct {
+	IsSlot bool
+	Slot   Slot
+	Source Source
+}
+
+// ParseRef parses a slot name or a terminal.sexy color name.
+func ParseRef(s string) (Ref, error) {
+	if slot, err := ParseSlot(s); err == nil {
+		return Ref{IsSlot: true, Slot: slot}, nil
+	}
+	if src, err := ParseSource(s); err == nil {
+		return Ref{Source: src}, nil
+	}
+	return Ref{}, fmt.Errorf("unknown color %q; expecting base00-base0F, color0-color15, foreground or background", s)
+}
+
+func (r Ref) String() string {
+	if r.IsSlot {
+		return r.Slot.String()
+	}
+	return r.Source.String()
+}
+
+func (r Ref) resolve(s *Scheme) Color {
+	if r.IsSlot {
+		return s.Colors[r.Slot]
+	}
+	return r.Source.resolve(s.Terminal)
+}
+
+// Role is a terminal UI color that is not one of the base16 slots.
+type Role int
+
+const (
+	RoleSelection Role = iota
+	RoleSelectedText
+	RoleCursor
+	RoleCursorText
+	RoleForeground
+	RoleBackground
+	numRoles
+)
+
+var roleNames = [numRoles]string{"selection", "selected-text", "cursor", "cursor-text", "foreground", "background"}
+
+func (r Role) String() string {
+	if r < 0 || r >= numRoles {
+		return fmt.Sprintf("Role(%d)", int(r))
+	}
+	return roleNames[r]
+}
+
+// defaultRoles are used for any role a mapping file leaves out.
+var defaultRoles = [numRoles]Ref{
+	RoleSelection:    {IsSlot: true, Slot: Base02},
+	RoleSelectedText: {IsSlot: true, Slot: Base05},
+	RoleCursor:       {IsSlot: true, Slot: Base05},
+	RoleCursorText:   {IsSlot: true, Slot: Base00},
+	RoleForeground:   {Source: SourceForeground},
+	RoleBackground:   {Source: SourceBackground},
+}
+
+// sourceDerived marks base10 and base11 when a mapping leaves them out;
+// they are then darkened from base00.
+const sourceDerived Source = -1
+
+// Mapping declares which terminal.sexy color feeds each base16 slot, and
+// which color each Role takes. A Base24 mapping also assigns the bright
+// slots base12-base17. An Auto mapping ignores Slots and assigns colors
+// perceptually instead.
+type Mapping struct {
+	Name   string
+	Auto   bool
+	Base24 bool
+	Slots  [NumSlots]Source
+	Roles  [numRoles]Ref
+}
+
+// ApplyRoles sets the cursor, selection, foreground and background colors
+// of s's terminal from m's roles. Roles naming foreground or background
+// take the values s had before.
+func (m Mapping) ApplyRoles(s *Scheme) {
+	var colors [numRoles]Color
+	for r, ref := range m.Roles {
+		colors[r] = ref.resolve(s)
+	}
+	s.Terminal.Selection = colors[RoleSelection]
+	s.Terminal.SelectedText = colors[RoleSelectedText]
+	s.Terminal.Cursor = colors[RoleCursor]
+	s.Terminal.CursorText = colors[RoleCursorText]
+	s.Terminal.Foreground = colors[RoleForeground]
+	s.Terminal.Background = colors[RoleBackground]
+}
+
+//go:embed mappings/*.yaml
+var mappingFiles embed.FS
+
+// DefaultMappingName is the built-in mapping used when none is selected.
+const DefaultMappingName = "default"
+
+// DefaultMapping returns the built-in default mapping.
+func DefaultMapping() Mapping {
+	m, err := BuiltinMapping(DefaultMappingName)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// BuiltinMapping returns the built-in mapping called name.
+func BuiltinMapping(name string) (Mapping, error) {
+	data, err := mappingFiles.ReadFile(path.Join("mappings", name+".yaml"))
+	if err != nil {
+		return Mapping{}, fmt.Errorf("no built-in mapping %q; choose one of %s", name, strings.Join(MappingNames(), ", "))
+	}
+	return ParseMapping(data)
+}
+
+// MappingNames lists the built-in mappings.
+func MappingNames() []string {
+	entries, _ := mappingFiles.ReadDir("mappings")
+	var names []string
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseMapping parses a mapping file. Every base16 slot must be assigned,
+// and base12-base17 either all or none of them:
+//
+//	name: mine
+//	slots:
+//	  base00: background
+//	  base08: color1
+//	  ...
+//	roles:
+//	  selection: base02
+//	  cursor: base05
+//
+// Roles are optional and take a slot or a terminal.sexy color. Instead of
+// slots, to classify the palette by hue and lightness, "mode: auto" is given
+// with no slots. Assigning base12-base17 makes the mapping base24; an auto
+// mapping asks for that with "system: base24".
+func ParseMapping(data []byte) (Mapping, error) {
+	doc, err := yaml.Parse(data)
+	if err != nil {
+		return Mapping{}, err
+	}
+	if doc.Kind != yaml.Mapping {
+		return Mapping{}, fmt.Errorf("line %d: expecting a mapping", doc.Line)
+	}
+
+	m := Mapping{Roles: defaultRoles}
+	var slots *yaml.Node
+	systemLine := 0
+	for _, p := range doc.Pairs {
+		switch p.Key {
+		case "name":
+			m.Name = p.Value.Value
+		case "mode":
+			switch p.Value.Value {
+			case "fixed":
+			case "auto":
+				m.Auto = true
+			default:
+				return Mapping{}, fmt.Errorf("line %d: unknown mode %q; expecting fixed or auto", p.Line, p.Value.Value)
+			}
+		case "system":
+			switch p.Value.Value {
+			case "base16":
+			case "base24":
+				m.Base24 = true
+			default:
+				return Mapping{}, fmt.Errorf("line %d: unknown system %q; expecting base16 or base24", p.Line, p.Value.Value)
+			}
+			systemLine = p.Line
+		case "slots":
+			slots = p.Value
+		case "roles":
+			if err := parseRoles(p.Value, &m); err != nil {
+				return Mapping{}, err
+			}
+		default:
+			return Mapping{}, fmt.Errorf("line %d: unknown key %q", p.Line, p.Key)
+		}
+	}
+	if m.Auto {
+		if slots != nil {
+			return Mapping{}, fmt.Errorf("line %d: slots cannot be combined with mode: auto", slots.Line)
+		}
+		return m, nil
+	}
+	if slots == nil || slots.Kind != yaml.Mapping {
+		return Mapping{}, fmt.Errorf("missing slots section")
+	}
+
+	var seen [NumSlots]bool
+	for _, p := range slots.Pairs {
+		slot, err := ParseSlot(p.Key)
+		if err != nil {
+			return Mapping{}, fmt.Errorf("line %d: %v", p.Line, err)
+		}
+		src, err := ParseSource(p.Value.Value)
+		if err != nil {
+			return Mapping{}, fmt.Errorf("line %d: %s: %v", p.Line, p.Key, err)
+		}
+		m.Slots[slot] = src
+		seen[slot] = true
+	}
+
+	// The base24 brights are all or nothing; the darker backgrounds may be
+	// left to be derived.
+	for _, slot := range base24Brights {
+		if seen[slot] {
+			if systemLine != 0 && !m.Base24 {
+				return Mapping{}, fmt.Errorf("line %d: %s needs system: base24", systemLine, slot)
+			}
+			m.Base24 = true
+		}
+	}
+	for _, slot := range []Slot{Base10, Base11} {
+		if !seen[slot] {
+			m.Slots[slot] = sourceDerived
+			seen[slot] = true
+		}
+	}
+
+	var missing []string
+	for i, ok := range seen {
+		if !ok && (i < NumBase16Slots || m.Base24) {
+			missing = append(missing, Slot(i).String())
+		}
+	}
+	if len(missing) > 0 {
+		return Mapping{}, fmt.Errorf("slots not assigned: %s", strings.Join(missing, ", "))
+	}
+	return m, nil
+}
+
+func parseRoles(n *yaml.Node, m *Mapping) error {
+	if n.Kind != yaml.Mapping {
+		return fmt.Errorf("line %d: roles must be a mapping", n.Line)
+	}
+	for _, p := range n.Pairs {
+		role := Role(-1)
+		for i, name := range roleNames {
+			if name == p.Key {
+				role = Role(i)
+			}
+		}
+		if role < 0 {
+			return fmt.Errorf("line %d: unknown role %q; expecting %s", p.Line, p.Key, strings.Join(roleNames[:], ", "))
+		}
+		ref, err := ParseRef(p.Value.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %s: %v", p.Line, p.Key, err)
+		}
+		m.Roles[role] = ref
+	}
+	return nil
+}
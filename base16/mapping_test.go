@@ -0,0 +1,44 @@
+package base16
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinMappingSystems(t *testing.T) {
+	for _, name := range MappingNames() {
+		m, err := BuiltinMapping(name)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if want := strings.HasSuffix(name, "24"); m.Base24 != want {
+			t.Errorf("%s: Base24 = %v, want %v", name, m.Base24, want)
+		}
+	}
+}
+
+func TestParseMappingSystem(t *testing.T) {
+	m, err := ParseMapping([]byte("mode: auto\nsystem: base24\n"))
+	if err != nil || !m.Auto || !m.Base24 {
+		t.Errorf("auto base24 mapping = %+v, %v", m, err)
+	}
+	_, err = ParseMapping([]byte("system: base16\nslots:\n  base12: color9\n"))
+	if err == nil || err.Error() != "line 1: base12 needs system: base24" {
+		t.Errorf("base16 mapping with base12: %v", err)
+	}
+	_, err = ParseMapping([]byte("system: base32\n"))
+	if err == nil || !strings.Contains(err.Error(), `unknown system "base32"`) {
+		t.Errorf("unknown system: %v", err)
+	}
+}
+
+func TestDefaultSelectionContrast(t *testing.T) {
+	for _, name := range []string{"default", "default24"} {
+		s := testScheme(t, name)
+		sel, text := toOKLab(s.Terminal.Selection).L, toOKLab(s.Terminal.SelectedText).L
+		if d := text - sel; d < 0.2 && d > -0.2 {
+			t.Errorf("%s: selection %v and selected text %v are too alike", name, s.Terminal.Selection, s.Terminal.SelectedText)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package base16
+
+import "math"
+
+// oklab is a color in the OKLab perceptual color space.
+type oklab struct {
+	L, A, B float64
+}
+
+func toOKLab(c Color) oklab {
+	r, g, b := c.Floats()
+	r, g, b = toLinear(r), toLinear(g), toLinear(b)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return oklab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// color converts back to sRGB, clipping out of gamut channels.
+func (o oklab) color() Color {
+	l := o.L + 0.3963377774*o.A + 0.2158037573*o.B
+	m := o.L - 0.1055613458*o.A - 0.0638541728*o.B
+	s := o.L - 0.0894841775*o.A - 1.2914855480*o.B
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	r := 4.0767416621*l - 3.3077115913*m + 0.2309699292*s
+	g := -1.2684380046*l + 2.6097574011*m - 0.3413193965*s
+	b := -0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+
+	return Color{R: toByte(fromLinear(r)), G: toByte(fromLinear(g)), B: toByte(fromLinear(b)), A: 255}
+}
+
+// lch returns the lightness, chroma and hue in degrees (OKLCH).
+func (o oklab) lch() (l, c, h float64) {
+	h = math.Atan2(o.B, o.A) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return o.L, math.Hypot(o.A, o.B), h
+}
+
+func fromLCH(l, c, h float64) oklab {
+	rad := h * math.Pi / 180
+	return oklab{L: l, A: c * math.Cos(rad), B: c * math.Sin(rad)}
+}
+
+// mix interpolates between o and p; t=0 is o and t=1 is p.
+func (o oklab) mix(p oklab, t float64) oklab {
+	return oklab{
+		L: o.L + (p.L-o.L)*t,
+		A: o.A + (p.A-o.A)*t,
+		B: o.B + (p.B-o.B)*t,
+	}
+}
+
+// distance is the Euclidean distance between two OKLab colors.
+func (o oklab) distance(p oklab) float64 {
+	return math.Sqrt((o.L-p.L)*(o.L-p.L) + (o.A-p.A)*(o.A-p.A) + (o.B-p.B)*(o.B-p.B))
+}
+
+func toLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func fromLinear(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// hueDistance is the angle between two hues in degrees, from 0 to 180.
+func hueDistance(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	if d > 180 {
+		d = 360 - d
+	}
+	return d
+}
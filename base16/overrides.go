@@ -0,0 +1,161 @@
+package base16
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RafaelPiloto10/base16-terminal-sexy/internal/yaml"
+)
+
+// HighlightOverride changes one neovim highlight group. Nil fields keep the
+// group's generated value; a group the colorschemes do not define is added.
+type HighlightOverride struct {
+	Group      string
+	Fg, Bg, Sp *Slot // nil keeps the color, NoSlot clears it
+	Attr       *string
+}
+
+// ParseHighlightOverrides parses an overrides file mapping each group to
+// the colors and attributes to change:
+//
+//	Comment:
+//	  attrs: italic
+//	Visual: {bg: base03}
+//	CursorLine:
+//	  bg: none
+//	  attrs: [bold, underline]
+//
+// Colors take a slot from base00 to base0F, or none to clear the color.
+// attrs replaces the group's attributes; an empty list clears them.
+func ParseHighlightOverrides(data []byte) ([]HighlightOverride, error) {
+	doc, err := yaml.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Kind == yaml.Scalar && doc.Value == "" {
+		return nil, nil
+	}
+	if doc.Kind != yaml.Mapping {
+		return nil, fmt.Errorf("line %d: expecting a mapping of highlight groups", doc.Line)
+	}
+
+	var overrides []HighlightOverride
+	for _, g := range doc.Pairs {
+		if !validGroupName(g.Key) {
+			return nil, fmt.Errorf("line %d: invalid group name %q; expecting letters, digits, '_', '.' or '@'", g.Line, g.Key)
+		}
+		if g.Value.Kind != yaml.Mapping {
+			return nil, fmt.Errorf("line %d: %s must be a mapping of fg, bg, sp and attrs", g.Line, g.Key)
+		}
+		o := HighlightOverride{Group: g.Key}
+		for _, p := range g.Value.Pairs {
+			switch p.Key {
+			case "fg", "bg", "sp":
+				slot, err := parseOverrideSlot(p.Value.Scalar())
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %s.%s: %v", p.Line, g.Key, p.Key, err)
+				}
+				switch p.Key {
+				case "fg":
+					o.Fg = &slot
+				case "bg":
+					o.Bg = &slot
+				case "sp":
+					o.Sp = &slot
+				}
+			case "attrs":
+				attr, err := parseOverrideAttrs(p.Value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %s.attrs: %v", p.Line, g.Key, err)
+				}
+				o.Attr = &attr
+			default:
+				return nil, fmt.Errorf("line %d: %s: unknown key %q; expecting fg, bg, sp or attrs", p.Line, g.Key, p.Key)
+			}
+		}
+		overrides = append(overrides, o)
+	}
+	return overrides, nil
+}
+
+// validGroupName reports whether name matches ^[@A-Za-z0-9_.]+$, so it
+// can be written into the colorschemes as is.
+func validGroupName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '_', r == '.', r == '@':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func parseOverrideSlot(s string) (Slot, error) {
+	if s == "none" || s == "" {
+		return NoSlot, nil
+	}
+	slot, err := ParseSlot(s)
+	if err != nil {
+		return 0, err
+	}
+	if slot >= NumBase16Slots {
+		return 0, fmt.Errorf("%s cannot be used in overrides; expecting base00-base0F or none", slot)
+	}
+	return slot, nil
+}
+
+// highlightAttrs are the attributes both :highlight and nvim_set_hl accept.
+var highlightAttrs = []string{
+	"bold", "italic", "underline", "undercurl", "underdouble", "underdotted",
+	"underdashed", "strikethrough", "reverse", "standout", "nocombine", "none",
+}
+
+func parseOverrideAttrs(n *yaml.Node) (string, error) {
+	var attrs []string
+	switch n.Kind {
+	case yaml.Sequence:
+		for _, item := range n.Items {
+			attrs = append(attrs, item.Scalar())
+		}
+	case yaml.Scalar:
+		for _, a := range strings.Split(n.Value, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				attrs = append(attrs, a)
+			}
+		}
+	default:
+		return "", fmt.Errorf("expecting a list of attributes")
+	}
+
+	for _, a := range attrs {
+		known := false
+		for _, h := range highlightAttrs {
+			known = known || a == h
+		}
+		if !known {
+			return "", fmt.Errorf("unknown attribute %q; expecting %s", a, strings.Join(highlightAttrs, ", "))
+		}
+	}
+	return strings.Join(attrs, ","), nil
+}
+
+// apply merges the override into h.
+func (o HighlightOverride) apply(h *highlight) {
+	if o.Fg != nil {
+		h.fg = *o.Fg
+	}
+	if o.Bg != nil {
+		h.bg = *o.Bg
+	}
+	if o.Sp != nil {
+		h.sp = *o.Sp
+	}
+	if o.Attr != nil {
+		h.attr = *o.Attr
+	}
+}
@@ -0,0 +1,50 @@
+package base16
+
+import "testing"
+
+func TestParseHighlightOverridesGroupNames(t *testing.T) {
+	tests := []struct {
+		in  string
+		err string // "" for a valid file
+	}{
+		{"Comment: {attrs: italic}\n\"@string.regexp\": {fg: base0C}\nMy_Group2: {bg: none}\n", ""},
+		{"Comment: {attrs: italic}\n\"Bad\\\"); os.execute(\\\"x\": {fg: base08}\n", `line 2: invalid group name "Bad\"); os.execute(\"x"; expecting letters, digits, '_', '.' or '@'`},
+		{"\"Two words\": {fg: base08}\n", `line 1: invalid group name "Two words"; expecting letters, digits, '_', '.' or '@'`},
+		{"\"\": {fg: base08}\n", `line 1: invalid group name ""; expecting letters, digits, '_', '.' or '@'`},
+	}
+	for _, tt := range tests {
+		_, err := ParseHighlightOverrides([]byte(tt.in))
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%q: %v", tt.in, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("%q: error = %v, want %s", tt.in, err, tt.err)
+		}
+	}
+}
+
+func TestNeovimVarsAddedGroups(t *testing.T) {
+	bg, fg := Base01, Base08
+	vars, err := NeovimVars(testScheme(t, "default"), NeovimOptions{Overrides: []HighlightOverride{
+		{Group: "MyGroup", Bg: &bg},
+		{Group: "@my.capture", Fg: &fg},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := vars["highlights"].([]map[string]any)
+	want := []struct {
+		group  string
+		neovim bool
+	}{{"MyGroup", false}, {"@my.capture", true}}
+	got := list[len(list)-len(want):]
+	for i, w := range want {
+		groups := got[i]["groups"].([]map[string]any)
+		if len(groups) != 1 || groups[0]["group"] != w.group || got[i]["neovim"] != w.neovim {
+			t.Errorf("section %q: groups %v, neovim %v; want only %s, neovim %v", got[i]["section"], groups, got[i]["neovim"], w.group, w.neovim)
+		}
+	}
+}
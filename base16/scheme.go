@@ -0,0 +1,204 @@
+// Package base16 converts terminal color themes into base16 schemes and
+// renders those schemes for the applications that consume them.
+package base16
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Slot names one of the base16 colors, or one of the eight extra colors
+// base24 adds.
+type Slot int
+
+const (
+	Base00 Slot = iota
+	Base01
+	Base02
+	Base03
+	Base04
+	Base05
+	Base06
+	Base07
+	Base08
+	Base09
+	Base0A
+	Base0B
+	Base0C
+	Base0D
+	Base0E
+	Base0F
+	Base10 // darker background
+	Base11 // darkest background
+	Base12 // bright red
+	Base13 // bright yellow
+	Base14 // bright green
+	Base15 // bright cyan
+	Base16 // bright blue
+	Base17 // bright magenta
+)
+
+// NumSlots is the number of colors in a base24 scheme. Base16 schemes use
+// the first NumBase16Slots.
+const (
+	NumSlots       = 24
+	NumBase16Slots = 16
+)
+
+var slotNames = [NumSlots]string{
+	"base00", "base01", "base02", "base03", "base04", "base05", "base06", "base07",
+	"base08", "base09", "base0A", "base0B", "base0C", "base0D", "base0E", "base0F",
+	"base10", "base11", "base12", "base13", "base14", "base15", "base16", "base17",
+}
+
+func (s Slot) String() string {
+	if s < 0 || int(s) >= len(slotNames) {
+		return fmt.Sprintf("Slot(%d)", int(s))
+	}
+	return slotNames[s]
+}
+
+// ParseSlot parses a slot name such as "base0A". The hex digit is case
+// insensitive.
+func ParseSlot(name string) (Slot, error) {
+	for i, n := range slotNames {
+		if strings.EqualFold(n, name) {
+			return Slot(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown slot %q; expecting base00-base0F or base10-base17", name)
+}
+
+// Terminal is the ANSI palette a scheme was built from, along with the
+// terminal's default foreground and background and the cursor and selection
+// colors chosen by the mapping's roles.
+type Terminal struct {
+	Foreground Color
+	Background Color
+	Colors     [16]Color
+
+	Cursor       Color
+	CursorText   Color
+	Selection    Color
+	SelectedText Color
+}
+
+// Scheme is a base16 or, if Base24 is set, a base24 color scheme. Colors
+// holds each slot, indexed by Slot. Slug is the file-name-safe form of Name.
+type Scheme struct {
+	Name     string
+	Author   string
+	Slug     string
+	Base24   bool
+	Colors   [NumSlots]Color
+	Terminal Terminal
+}
+
+// Slots lists the slots the scheme uses: sixteen, or twenty four for base24.
+func (s *Scheme) Slots() []Slot {
+	n := NumBase16Slots
+	if s.Base24 {
+		n = NumSlots
+	}
+	slots := make([]Slot, n)
+	for i := range slots {
+		slots[i] = Slot(i)
+	}
+	return slots
+}
+
+// System returns "base24" for base24 schemes and "base16" otherwise.
+func (s *Scheme) System() string {
+	if s.Base24 {
+		return "base24"
+	}
+	return "base16"
+}
+
+// IsLight reports whether the scheme has a light background, judged by the
+// terminal background's OKLab lightness.
+func (s *Scheme) IsLight() bool {
+	return toOKLab(s.Terminal.Background).L > 0.5
+}
+
+// Variant returns "light" or "dark".
+func (s *Scheme) Variant() string {
+	if s.IsLight() {
+		return "light"
+	}
+	return "dark"
+}
+
+// base24Brights are the base24 slots holding bright red, green, yellow,
+// blue, magenta and cyan, in ANSI order (color9-color14).
+var base24Brights = [6]Slot{Base12, Base14, Base13, Base16, Base17, Base15}
+
+// darkenBackgrounds fills base10 and base11 with progressively darker
+// versions of base00.
+func (s *Scheme) darkenBackgrounds() {
+	bg := toOKLab(s.Colors[Base00])
+	s.Colors[Base10] = bg.mix(oklab{}, 0.25).color()
+	s.Colors[Base11] = bg.mix(oklab{}, 0.5).color()
+}
+
+// Color returns the color assigned to slot.
+func (s *Scheme) Color(slot Slot) Color {
+	return s.Colors[slot]
+}
+
+// Inverted returns the opposite light or dark variant of s. A base00-base07
+// ramp that runs evenly in lightness is reversed; one that doesn't, such as
+// the default mapping's with an accent in base07, is replaced by a new ramp
+// built from base00 and base05 the way the auto mapping builds one. Accents
+// are kept. Terminal colors taken from the ramp, like the cursor or color0,
+// move with their slot, and the terminal's foreground and background become
+// base05 and base00. Name and Slug are left for the caller to change.
+func (s Scheme) Inverted() Scheme {
+	inv := s
+	follow := []Slot{Base00, Base01, Base02, Base03, Base04, Base05, Base06, Base07}
+	if s.rampIsMonotonic() {
+		for i := 0; i < 8; i++ {
+			inv.Colors[i] = s.Colors[7-i]
+		}
+	} else {
+		// The old ramp's far end becomes the new background.
+		old := grayRamp(s.Colors[Base00], s.Colors[Base05])
+		ramp := grayRamp(old[Base07], s.Colors[Base00])
+		copy(inv.Colors[:], ramp[:])
+		follow = []Slot{Base00, Base05}
+	}
+	if inv.Base24 {
+		inv.darkenBackgrounds()
+	}
+
+	move := func(c *Color) {
+		for _, slot := range follow {
+			if *c == s.Colors[slot] {
+				*c = inv.Colors[slot]
+				return
+			}
+		}
+	}
+	t := &inv.Terminal
+	for i := range t.Colors {
+		move(&t.Colors[i])
+	}
+	for _, c := range []*Color{&t.Cursor, &t.CursorText, &t.Selection, &t.SelectedText} {
+		move(c)
+	}
+	t.Background = inv.Colors[Base00]
+	t.Foreground = inv.Colors[Base05]
+	return inv
+}
+
+// rampIsMonotonic reports whether base00-base07 only get lighter, or only
+// darker.
+func (s *Scheme) rampIsMonotonic() bool {
+	up, down := true, true
+	for i := 1; i < 8; i++ {
+		prev, cur := toOKLab(s.Colors[i-1]).L, toOKLab(s.Colors[i]).L
+		up = up && cur >= prev
+		down = down && cur <= prev
+	}
+	return up || down
+}
@@ -0,0 +1,64 @@
+package base16
+
+import "testing"
+
+func grey(v uint8) Color { return Color{v, v, v, 0xff} }
+
+func TestInvertedReversesEvenRamp(t *testing.T) {
+	var s Scheme
+	for i := 0; i < 8; i++ {
+		s.Colors[i] = grey(uint8(0x10 + 0x20*i))
+	}
+	s.Colors[Base08] = Color{0xcc, 0x66, 0x66, 0xff}
+	s.Terminal.Background = s.Colors[Base00]
+	s.Terminal.Foreground = s.Colors[Base05]
+	s.Terminal.Colors[0] = s.Colors[Base00]
+	s.Terminal.Colors[1] = s.Colors[Base08]
+	s.Terminal.Cursor = s.Colors[Base05]
+
+	inv := s.Inverted()
+	for i := 0; i < 8; i++ {
+		if inv.Colors[i] != s.Colors[7-i] {
+			t.Errorf("base0%d = %v, want %v", i, inv.Colors[i], s.Colors[7-i])
+		}
+	}
+	if !inv.IsLight() || inv.Terminal.Background != s.Colors[Base07] || inv.Terminal.Foreground != s.Colors[Base02] {
+		t.Errorf("terminal background %v, foreground %v", inv.Terminal.Background, inv.Terminal.Foreground)
+	}
+	if inv.Colors[Base08] != s.Colors[Base08] || inv.Terminal.Colors[1] != s.Colors[Base08] {
+		t.Error("accents changed")
+	}
+	if inv.Terminal.Colors[0] != inv.Colors[Base00] || inv.Terminal.Cursor != inv.Colors[Base05] {
+		t.Errorf("color0 %v and cursor %v did not follow the ramp", inv.Terminal.Colors[0], inv.Terminal.Cursor)
+	}
+}
+
+func TestInvertedRebuildsUnevenRamp(t *testing.T) {
+	// The default mapping puts bright red in base07.
+	red := Color{0xd5, 0x4e, 0x53, 0xff}
+	s := Scheme{Name: "Tomorrow Night", Slug: "tomorrow-night"}
+	s.Colors[Base00], s.Colors[Base01] = grey(0x1d), grey(0x1d)
+	s.Colors[Base02], s.Colors[Base03] = grey(0xc5), grey(0x96)
+	s.Colors[Base04] = Color{0x70, 0xc0, 0xb1, 0xff}
+	s.Colors[Base05], s.Colors[Base06] = grey(0xea), grey(0xea)
+	s.Colors[Base07], s.Colors[Base08] = red, red
+	s.Terminal.Background, s.Terminal.Foreground = grey(0x1d), grey(0xea)
+	s.Terminal.Colors[9] = red
+
+	inv := s.Inverted()
+	if !inv.IsLight() {
+		t.Fatalf("background %v is not light", inv.Colors[Base00])
+	}
+	if !inv.rampIsMonotonic() {
+		t.Errorf("rebuilt ramp is uneven: %v", inv.Colors[:8])
+	}
+	if inv.Colors[Base05] != s.Colors[Base00] {
+		t.Errorf("base05 = %v, want the old background", inv.Colors[Base05])
+	}
+	if inv.Colors[Base08] != red || inv.Terminal.Colors[9] != red {
+		t.Error("red accent changed")
+	}
+	if inv.Name != s.Name || inv.Slug != s.Slug {
+		t.Errorf("name and slug changed to %q, %q", inv.Name, inv.Slug)
+	}
+}
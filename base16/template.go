@@ -0,0 +1,226 @@
+package base16
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/RafaelPiloto10/base16-terminal-sexy/internal/mustache"
+	"github.com/RafaelPiloto10/base16-terminal-sexy/internal/yaml"
+)
+
+// TemplateVars returns the variables base16 builder templates expect:
+// scheme-name, scheme-author, scheme-slug, scheme-system, scheme-variant
+// and, for every slot, base00-hex, base00-hex-r, base00-hex-bgr,
+// base00-rgb-r, base00-dec-r and so on. The terminal palette is exposed the
+// same way as terminal-color00 through terminal-color15,
+// terminal-foreground, terminal-background, terminal-cursor,
+// terminal-cursor-text, terminal-selection and terminal-selected-text.
+// scheme-name-comment and scheme-author-comment are safe to put in a
+// single-line comment: control characters, line breaks included, become
+// spaces.
+func TemplateVars(s Scheme) map[string]any {
+	vars := map[string]any{
+		"scheme-name":             s.Name,
+		"scheme-name-comment":     commentSafe(s.Name),
+		"scheme-author":           s.Author,
+		"scheme-author-comment":   commentSafe(s.Author),
+		"scheme-slug":             s.Slug,
+		"scheme-slug-underscored": strings.ReplaceAll(s.Slug, "-", "_"),
+		"scheme-system":           s.System(),
+		"scheme-variant":          s.Variant(),
+		"scheme-is-dark-variant":  !s.IsLight(),
+		"scheme-is-light-variant": s.IsLight(),
+		"scheme-is-base24":        s.Base24,
+	}
+
+	for _, slot := range s.Slots() {
+		addColorVars(vars, slot.String(), s.Colors[slot])
+	}
+
+	t := s.Terminal
+	for i, c := range t.Colors {
+		addColorVars(vars, fmt.Sprintf("terminal-color%02d", i), c)
+	}
+	addColorVars(vars, "terminal-foreground", t.Foreground)
+	addColorVars(vars, "terminal-background", t.Background)
+	addColorVars(vars, "terminal-cursor", t.Cursor)
+	addColorVars(vars, "terminal-cursor-text", t.CursorText)
+	addColorVars(vars, "terminal-selection", t.Selection)
+	addColorVars(vars, "terminal-selected-text", t.SelectedText)
+	return vars
+}
+
+// commentSafe replaces control characters and line separators in s with
+// spaces, so that s can't end a line comment.
+func commentSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '\u2028' || r == '\u2029' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// escapeString escapes s for a double-quoted string in Vim script or Lua,
+// which share the \\, \" and \xXX escapes.
+func escapeString(s string) string {
+	return escapeQuoted(s, "\\x%02x")
+}
+
+// escapeTOML escapes s for a TOML basic string.
+func escapeTOML(s string) string {
+	return escapeQuoted(s, "\\u%04x")
+}
+
+// escapeQuoted backslash-escapes quotes and backslashes in s, and writes
+// control characters with the format ctl.
+func escapeQuoted(s, ctl string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' || c == '"':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c == 0x7f:
+			fmt.Fprintf(&b, ctl, c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func addColorVars(vars map[string]any, name string, c Color) {
+	hex := c.Hex()
+	vars[name+"-hex"] = hex
+	vars[name+"-hex-r"] = hex[0:2]
+	vars[name+"-hex-g"] = hex[2:4]
+	vars[name+"-hex-b"] = hex[4:6]
+	vars[name+"-hex-bgr"] = hex[4:6] + hex[2:4] + hex[0:2]
+
+	r, g, b := c.Ints()
+	vars[name+"-rgb-r"] = strconv.Itoa(r)
+	vars[name+"-rgb-g"] = strconv.Itoa(g)
+	vars[name+"-rgb-b"] = strconv.Itoa(b)
+
+	fr, fg, fb := c.Floats()
+	vars[name+"-dec-r"] = strconv.FormatFloat(fr, 'f', -1, 64)
+	vars[name+"-dec-g"] = strconv.FormatFloat(fg, 'f', -1, 64)
+	vars[name+"-dec-b"] = strconv.FormatFloat(fb, 'f', -1, 64)
+}
+
+// RenderTemplate renders a Mustache template against s's TemplateVars.
+func RenderTemplate(src string, s Scheme) ([]byte, error) {
+	return RenderTemplateVars(src, TemplateVars(s))
+}
+
+// RenderTemplateVars renders a Mustache template against vars, such as
+// those returned by TemplateVars or NeovimVars.
+func RenderTemplateVars(src string, vars map[string]any) ([]byte, error) {
+	out, err := mustache.Render(src, vars, nil)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+// Template is one entry of a base16 template repository.
+type Template struct {
+	Name      string
+	Extension string
+	Output    string
+	Filename  string // Mustache pattern for the output file name
+	Source    string
+}
+
+// defaultFilename is the output file name builders use when the config
+// gives only an extension.
+const defaultFilename = "{{scheme-system}}-{{scheme-slug}}"
+
+// LoadTemplates reads a template repository laid out the way base16
+// builders expect:
+//
+//	templates/config.yaml
+//	templates/<name>.mustache
+//
+// where config.yaml maps each template name to its extension and output
+// folder:
+//
+//	default:
+//	  extension: .vim
+//	  output: colors
+//
+// dir may be the repository root or its templates folder.
+func LoadTemplates(dir string) ([]Template, error) {
+	if _, err := os.Stat(filepath.Join(dir, "templates", "config.yaml")); err == nil {
+		dir = filepath.Join(dir, "templates")
+	}
+
+	configPath := filepath.Join(dir, "config.yaml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := yaml.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
+	if doc.Kind != yaml.Mapping {
+		return nil, fmt.Errorf("%s: expecting a mapping of template names", configPath)
+	}
+
+	var templates []Template
+	for _, p := range doc.Pairs {
+		if p.Value.Kind != yaml.Mapping {
+			return nil, fmt.Errorf("%s: line %d: %s must be a mapping", configPath, p.Line, p.Key)
+		}
+		t := Template{
+			Name:      p.Key,
+			Extension: nullable(p.Value.Get("extension").Scalar()),
+			Output:    nullable(p.Value.Get("output").Scalar()),
+			Filename:  nullable(p.Value.Get("filename").Scalar()),
+		}
+		if t.Filename == "" {
+			t.Filename = defaultFilename + t.Extension
+		}
+
+		src, err := os.ReadFile(filepath.Join(dir, p.Key+".mustache"))
+		if err != nil {
+			return nil, err
+		}
+		t.Source = string(src)
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+func nullable(s string) string {
+	if s == "~" || s == "null" {
+		return ""
+	}
+	return s
+}
+
+// Render renders the template for s. It returns the output file's path,
+// relative to the repository root, and its contents. Paths that would leave
+// the root, being absolute or climbing out with "..", are an error.
+func (t Template) Render(s Scheme) (string, []byte, error) {
+	vars := TemplateVars(s)
+	name, err := mustache.Render(t.Filename, vars, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("template %s: filename: %w", t.Name, err)
+	}
+	file := filepath.Join(t.Output, name)
+	if !filepath.IsLocal(file) {
+		return "", nil, fmt.Errorf("template %s: output path %q is outside the output folder", t.Name, file)
+	}
+	out, err := mustache.Render(t.Source, vars, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	return file, []byte(out), nil
+}
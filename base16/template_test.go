@@ -0,0 +1,44 @@
+package base16
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTemplateRenderPath(t *testing.T) {
+	s := Scheme{Name: "Tomorrow Night", Slug: "tomorrow-night"}
+	tests := []struct {
+		output, filename string
+		want             string // "" for a refused path
+	}{
+		{"colors", defaultFilename + ".vim", "colors/base16-tomorrow-night.vim"},
+		{"", "{{scheme-slug}}.conf", "tomorrow-night.conf"},
+		{"a/../b", "{{scheme-slug}}", "b/tomorrow-night"},
+		{"..", "{{scheme-slug}}", ""},
+		{"colors", "../../{{scheme-slug}}", ""},
+		{"/tmp", "{{scheme-slug}}", ""},
+	}
+	for _, tt := range tests {
+		tpl := Template{Name: "t", Output: tt.output, Filename: tt.filename}
+		file, _, err := tpl.Render(s)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("output %q, filename %q: got %q, want an error", tt.output, tt.filename, file)
+			}
+			continue
+		}
+		if err != nil || file != filepath.FromSlash(tt.want) {
+			t.Errorf("output %q, filename %q: got %q, %v, want %q", tt.output, tt.filename, file, err, tt.want)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	in := "a \"b\" \\c\n\x7f"
+	if got, want := escapeString(in), `a \"b\" \\c\x0a\x7f`; got != want {
+		t.Errorf("escapeString = %q, want %q", got, want)
+	}
+	if got, want := escapeTOML(in), `a \"b\" \\c\u000a\u007f`; got != want {
+		t.Errorf("escapeTOML = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+package base16
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Base16JSON is a theme as exported by https://terminal.sexy.
+type Base16JSON struct {
+	Name       string
+	Author     string
+	Color      []string
+	Foreground string
+	Background string
+}
+
+// ParseJSON decodes a terminal.sexy JSON export.
+func ParseJSON(data []byte) (Base16JSON, error) {
+	bj := Base16JSON{}
+	if err := json.Unmarshal(data, &bj); err != nil {
+		return Base16JSON{}, err
+	}
+	return bj, nil
+}
+
+// FromJSON builds a Scheme from a terminal.sexy export using the default
+// mapping.
+func FromJSON(bj Base16JSON) (Scheme, error) {
+	return FromJSONMapping(bj, DefaultMapping())
+}
+
+// FromJSONMapping builds a Scheme from a terminal.sexy export, filling each
+// slot from the color m assigns to it. An eight color export reuses
+// color0-color7 as its bright colors; see DeriveBrights for an alternative.
+func FromJSONMapping(bj Base16JSON, m Mapping) (Scheme, error) {
+	if err := Validate(bj); err != nil {
+		return Scheme{}, err
+	}
+	if len(bj.Color) == 8 {
+		bj.Color = append(bj.Color[:8:8], bj.Color...)
+	}
+
+	s := Scheme{
+		Name:   bj.Name,
+		Author: bj.Author,
+		Slug:   Slugify(bj.Name),
+	}
+
+	var err error
+	if s.Terminal.Foreground, err = parseField("foreground", bj.Foreground); err != nil {
+		return Scheme{}, err
+	}
+	if s.Terminal.Background, err = parseField("background", bj.Background); err != nil {
+		return Scheme{}, err
+	}
+	for i := range s.Terminal.Colors {
+		if s.Terminal.Colors[i], err = parseField(fmt.Sprintf("color[%d]", i), bj.Color[i]); err != nil {
+			return Scheme{}, err
+		}
+	}
+	if m.Auto {
+		s.Colors = autoAssign(s.Terminal)
+		if m.Base24 {
+			// The export's own brights become the base24 brights.
+			s.Base24 = true
+			for i, slot := range base24Brights {
+				s.Colors[slot] = s.Terminal.Colors[9+i]
+			}
+			s.darkenBackgrounds()
+		}
+	} else {
+		s.Base24 = m.Base24
+		for _, slot := range s.Slots() {
+			if m.Slots[slot] != sourceDerived {
+				s.Colors[slot] = m.Slots[slot].resolve(s.Terminal)
+			}
+		}
+		if s.Base24 {
+			derived := s.Colors
+			s.darkenBackgrounds()
+			for _, slot := range []Slot{Base10, Base11} {
+				if m.Slots[slot] != sourceDerived {
+					s.Colors[slot] = derived[slot]
+				}
+			}
+			// The terminal's brights follow the base24 slots, so a mapping can
+			// swap them.
+			for i, slot := range base24Brights {
+				s.Terminal.Colors[9+i] = s.Colors[slot]
+			}
+		}
+	}
+	m.ApplyRoles(&s)
+
+	return s, nil
+}
+
+func parseField(field, value string) (Color, error) {
+	c, err := ParseColor(value)
+	if err != nil {
+		return Color{}, fmt.Errorf("%s: %w", field, err)
+	}
+	return c, nil
+}
+
+// brightBoost is how far DeriveBrights moves each color's OKLCH lightness
+// towards white.
+const brightBoost = 0.25
+
+// DeriveBrights returns bj with color8-color15 derived from color0-color7 by
+// raising their lightness in OKLCH, keeping chroma and hue. Exports that
+// already have sixteen colors, or whose colors do not parse, are returned
+// unchanged.
+func DeriveBrights(bj Base16JSON) Base16JSON {
+	if len(bj.Color) != 8 {
+		return bj
+	}
+
+	colors := append([]string(nil), bj.Color...)
+	for _, hex := range bj.Color {
+		c, err := ParseColor(hex)
+		if err != nil {
+			return bj
+		}
+		l, ch, h := toOKLab(c).lch()
+		colors = append(colors, fromLCH(l+(1-l)*brightBoost, ch, h).color().String())
+	}
+	bj.Color = colors
+	return bj
+}
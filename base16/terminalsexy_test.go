@@ -0,0 +1,61 @@
+package base16
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFromJSONKeepsBrights(t *testing.T) {
+	for _, mapping := range []string{"default", "default24", "auto24"} {
+		s := testScheme(t, mapping)
+		for i := 9; i <= 14; i++ {
+			if want := mustColor(t, tomorrowNight.Color[i]); s.Terminal.Colors[i] != want {
+				t.Errorf("%s: color%d = %v, want %v", mapping, i, s.Terminal.Colors[i], want)
+			}
+		}
+	}
+
+	s := testScheme(t, "default24")
+	for i, slot := range base24Brights {
+		if want := mustColor(t, tomorrowNight.Color[9+i]); s.Colors[slot] != want {
+			t.Errorf("%s = %v, want color%d %v", slot, s.Colors[slot], 9+i, want)
+		}
+	}
+}
+
+func TestFromJSONEightColors(t *testing.T) {
+	bj := tomorrowNight
+	bj.Color = bj.Color[:8]
+	s, err := FromJSON(bj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 8; i++ {
+		if s.Terminal.Colors[8+i] != s.Terminal.Colors[i] {
+			t.Errorf("color%d = %v, want color%d %v", 8+i, s.Terminal.Colors[8+i], i, s.Terminal.Colors[i])
+		}
+	}
+}
+
+func TestDeriveBrights(t *testing.T) {
+	if got := DeriveBrights(tomorrowNight); !reflect.DeepEqual(got, tomorrowNight) {
+		t.Errorf("sixteen color export changed: %v", got.Color)
+	}
+
+	bj := tomorrowNight
+	bj.Color = bj.Color[:8]
+	got := DeriveBrights(bj)
+	if len(got.Color) != 16 || !reflect.DeepEqual(got.Color[:8], bj.Color) {
+		t.Fatalf("colors = %v", got.Color)
+	}
+	for i := 0; i < 8; i++ {
+		l, c, h := toOKLab(mustColor(t, got.Color[i])).lch()
+		bl, bc, bh := toOKLab(mustColor(t, got.Color[8+i])).lch()
+		if bl <= l {
+			t.Errorf("color%d %s is not lighter than color%d %s", 8+i, got.Color[8+i], i, got.Color[i])
+		}
+		if c >= minChroma && (hueDistance(h, bh) > 3 || bc < minChroma) {
+			t.Errorf("color%d %s has hue %.1f, color%d %s has %.1f", 8+i, got.Color[8+i], bh, i, got.Color[i], h)
+		}
+	}
+}
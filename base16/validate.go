@@ -0,0 +1,68 @@
+package base16
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Problem is a single defect found while validating a theme.
+type Problem struct {
+	Field   string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Field, p.Message)
+}
+
+// Problems is the error returned by Validate. It lists every defect found
+// rather than stopping at the first one.
+type Problems []Problem
+
+func (ps Problems) Error() string {
+	msgs := make([]string, len(ps))
+	for i, p := range ps {
+		msgs[i] = p.String()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate checks that a terminal.sexy export has eight or sixteen colors,
+// a foreground and a background, and that every color parses. It returns
+// nil or a Problems value.
+func Validate(bj Base16JSON) error {
+	var ps Problems
+	add := func(field, format string, args ...any) {
+		ps = append(ps, Problem{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	check := func(field, value string) {
+		if value == "" {
+			add(field, "missing")
+		} else if _, err := ParseColor(value); err != nil {
+			add(field, "%v", err)
+		}
+	}
+
+	check("foreground", bj.Foreground)
+	check("background", bj.Background)
+
+	switch n := len(bj.Color); {
+	case n < 8:
+		add("color", "has %d colors; missing color[%d] through color[7]", n, n)
+	case n > 8 && n < 16:
+		add("color", "has %d colors; missing color[%d] through color[15]", n, n)
+	case n > 16:
+		add("color", "has %d colors; unexpected color[16] through color[%d]", n, n-1)
+	}
+	for i, c := range bj.Color {
+		if i >= 16 {
+			break
+		}
+		check(fmt.Sprintf("color[%d]", i), c)
+	}
+
+	if len(ps) > 0 {
+		return ps
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package base16
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func colors(n int) []string {
+	c := make([]string, n)
+	for i := range c {
+		c[i] = "#101010"
+	}
+	return c
+}
+
+func TestValidate(t *testing.T) {
+	bad := colors(16)
+	bad[3] = "#12345g"
+
+	tests := []struct {
+		name string
+		bj   Base16JSON
+		want Problems
+	}{
+		{"eight colors", Base16JSON{Color: colors(8), Foreground: "#fff", Background: "#000"}, nil},
+		{"sixteen colors", Base16JSON{Color: colors(16), Foreground: "#fff", Background: "#000"}, nil},
+		{"no colors", Base16JSON{Foreground: "#fff", Background: "#000"}, Problems{
+			{"color", "has 0 colors; missing color[0] through color[7]"},
+		}},
+		{"seven colors", Base16JSON{Color: colors(7), Foreground: "#fff", Background: "#000"}, Problems{
+			{"color", "has 7 colors; missing color[7] through color[7]"},
+		}},
+		{"twelve colors", Base16JSON{Color: colors(12), Foreground: "#fff", Background: "#000"}, Problems{
+			{"color", "has 12 colors; missing color[12] through color[15]"},
+		}},
+		{"seventeen colors", Base16JSON{Color: colors(17), Foreground: "#fff", Background: "#000"}, Problems{
+			{"color", "has 17 colors; unexpected color[16] through color[16]"},
+		}},
+		{"everything at once", Base16JSON{Color: append(bad, "#000"), Background: "notacolor"}, Problems{
+			{"foreground", "missing"},
+			{"background", `invalid color "notacolor" at position 0: unknown color name "notacolor"`},
+			{"color", "has 17 colors; unexpected color[16] through color[16]"},
+			{"color[3]", `invalid color "#12345g" at position 6: invalid hex digit 'g'`},
+		}},
+	}
+	for _, tt := range tests {
+		err := Validate(tt.bj)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			}
+			continue
+		}
+		var ps Problems
+		if !errors.As(err, &ps) {
+			t.Errorf("%s: error = %v, want Problems", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(ps, tt.want) {
+			t.Errorf("%s:\ngot  %v\nwant %v", tt.name, ps, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package base16
+
+import "encoding/json"
+
+// windowsTerminalScheme is an entry of the "schemes" list in Windows
+// Terminal's settings.
+type windowsTerminalScheme struct {
+	Name                string `json:"name"`
+	Foreground          string `json:"foreground"`
+	Background          string `json:"background"`
+	CursorColor         string `json:"cursorColor"`
+	SelectionBackground string `json:"selectionBackground"`
+	Black               string `json:"black"`
+	Red                 string `json:"red"`
+	Green               string `json:"green"`
+	Yellow              string `json:"yellow"`
+	Blue                string `json:"blue"`
+	Purple              string `json:"purple"`
+	Cyan                string `json:"cyan"`
+	White               string `json:"white"`
+	BrightBlack         string `json:"brightBlack"`
+	BrightRed           string `json:"brightRed"`
+	BrightGreen         string `json:"brightGreen"`
+	BrightYellow        string `json:"brightYellow"`
+	BrightBlue          string `json:"brightBlue"`
+	BrightPurple        string `json:"brightPurple"`
+	BrightCyan          string `json:"brightCyan"`
+	BrightWhite         string `json:"brightWhite"`
+}
+
+// GenerateWindowsTerminal renders s as a Windows Terminal settings fragment,
+// {"schemes": [...]}, holding one scheme named base16-<slug>. The scheme can
+// also be pasted into the "schemes" list of settings.json.
+func GenerateWindowsTerminal(s Scheme) ([]byte, error) {
+	return GenerateWindowsTerminalNamed(s, "base16-"+s.Slug)
+}
+
+// GenerateWindowsTerminalNamed is GenerateWindowsTerminal with the scheme
+// named name, which should match the file's name.
+func GenerateWindowsTerminalNamed(s Scheme, name string) ([]byte, error) {
+	t := s.Terminal
+	c := t.Colors
+	scheme := windowsTerminalScheme{
+		Name:                name,
+		Foreground:          t.Foreground.String(),
+		Background:          t.Background.String(),
+		CursorColor:         t.Cursor.String(),
+		SelectionBackground: t.Selection.String(),
+		Black:               c[0].String(),
+		Red:                 c[1].String(),
+		Green:               c[2].String(),
+		Yellow:              c[3].String(),
+		Blue:                c[4].String(),
+		Purple:              c[5].String(),
+		Cyan:                c[6].String(),
+		White:               c[7].String(),
+		BrightBlack:         c[8].String(),
+		BrightRed:           c[9].String(),
+		BrightGreen:         c[10].String(),
+		BrightYellow:        c[11].String(),
+		BrightBlue:          c[12].String(),
+		BrightPurple:        c[13].String(),
+		BrightCyan:          c[14].String(),
+		BrightWhite:         c[15].String(),
+	}
+
+	data, err := json.MarshalIndent(map[string][]windowsTerminalScheme{"schemes": {scheme}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
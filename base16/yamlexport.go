@@ -0,0 +1,41 @@
+package base16
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Slugify turns a scheme name into the lowercase, hyphenated form used for
+// file names and the base16 slug field.
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// GenerateYAML renders s as a base16 scheme file, or a base24 one if
+// s.Base24 is set.
+func GenerateYAML(s Scheme) ([]byte, error) {
+	var b strings.Builder
+	if s.Base24 {
+		fmt.Fprintf(&b, "system: %q\n", "base24")
+	}
+	fmt.Fprintf(&b, "scheme: %q\n", s.Name)
+	fmt.Fprintf(&b, "author: %q\n", s.Author)
+	fmt.Fprintf(&b, "slug: %q\n", s.Slug)
+	for _, slot := range s.Slots() {
+		fmt.Fprintf(&b, "%s: %q\n", slot, s.Colors[slot].Hex())
+	}
+	return []byte(b.String()), nil
+}
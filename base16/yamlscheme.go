@@ -0,0 +1,104 @@
+package base16
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RafaelPiloto10/base16-terminal-sexy/internal/yaml"
+)
+
+// ansiSlots is the base16-shell assignment of slots to the sixteen ANSI
+// colors, used when a scheme has no terminal palette of its own.
+var ansiSlots = [16]Slot{
+	Base00, Base08, Base0B, Base0A, Base0D, Base0E, Base0C, Base05,
+	Base03, Base08, Base0B, Base0A, Base0D, Base0E, Base0C, Base07,
+}
+
+// ansiSlots24 is the base24 equivalent, with dedicated bright colors.
+var ansiSlots24 = [16]Slot{
+	Base00, Base08, Base0B, Base0A, Base0D, Base0E, Base0C, Base06,
+	Base02, Base12, Base14, Base13, Base16, Base17, Base15, Base07,
+}
+
+// ParseYAML parses a base16 or base24 scheme file. Both the original layout
+//
+//	scheme: "Tomorrow Night"
+//	author: "Chris Kempson"
+//	base00: "1d1f21"
+//	...
+//
+// and the newer one with name, author and a palette section are accepted.
+// Colors may be written with or without a leading '#'. A scheme is base24
+// when it declares "system: base24" or has any of base10-base17. Every
+// problem found is returned together as a Problems error.
+func ParseYAML(data []byte) (Scheme, error) {
+	doc, err := yaml.Parse(data)
+	if err != nil {
+		return Scheme{}, err
+	}
+	if doc.Kind != yaml.Mapping {
+		return Scheme{}, fmt.Errorf("line %d: expecting a mapping", doc.Line)
+	}
+
+	s := Scheme{}
+	palette := doc
+	if p := doc.Get("palette"); p != nil {
+		palette = p
+		s.Name = doc.Get("name").Scalar()
+	} else {
+		s.Name = doc.Get("scheme").Scalar()
+	}
+	s.Author = doc.Get("author").Scalar()
+	s.Slug = doc.Get("slug").Scalar()
+	if s.Slug == "" {
+		s.Slug = Slugify(s.Name)
+	}
+
+	lookup := func(slot Slot) *yaml.Node {
+		if value := palette.Get(slot.String()); value != nil {
+			return value
+		}
+		return palette.Get(strings.ToLower(slot.String()))
+	}
+	s.Base24 = doc.Get("system").Scalar() == "base24"
+	for slot := Base10; slot <= Base17; slot++ {
+		if lookup(slot) != nil {
+			s.Base24 = true
+		}
+	}
+
+	var ps Problems
+	for _, slot := range s.Slots() {
+		value := lookup(slot)
+		if value == nil || value.Value == "" {
+			ps = append(ps, Problem{Field: slot.String(), Message: "missing"})
+			continue
+		}
+
+		hex := value.Value
+		if !strings.HasPrefix(hex, "#") {
+			hex = "#" + hex
+		}
+		c, err := ParseColor(hex)
+		if err != nil {
+			ps = append(ps, Problem{Field: slot.String(), Message: fmt.Sprintf("line %d: %v", value.Line, err)})
+			continue
+		}
+		s.Colors[slot] = c
+	}
+	if len(ps) > 0 {
+		return Scheme{}, ps
+	}
+
+	s.Terminal.Foreground = s.Colors[Base05]
+	s.Terminal.Background = s.Colors[Base00]
+	slots := ansiSlots
+	if s.Base24 {
+		slots = ansiSlots24
+	}
+	for i, slot := range slots {
+		s.Terminal.Colors[i] = s.Colors[slot]
+	}
+	DefaultMapping().ApplyRoles(&s)
+	return s, nil
+}
@@ -0,0 +1,35 @@
+package base16
+
+import "testing"
+
+func TestParseYAMLPlainAuthor(t *testing.T) {
+	src := `scheme: Tomorrow Night [eighties]
+author: Chris Kempson (http://chriskempson.com), ported by someone, 2024
+base00: "2d2d2d"
+base01: "393939"
+base02: "515151"
+base03: "999999"
+base04: "b4b7b4"
+base05: "cccccc"
+base06: "e0e0e0"
+base07: "ffffff"
+base08: "f2777a"
+base09: "f99157"
+base0A: "ffcc66"
+base0B: "99cc99"
+base0C: "66cccc"
+base0D: "6699cc"
+base0E: "cc99cc"
+base0F: "a3685a"
+`
+	s, err := ParseYAML([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != "Tomorrow Night [eighties]" {
+		t.Errorf("Name = %q", s.Name)
+	}
+	if s.Author != "Chris Kempson (http://chriskempson.com), ported by someone, 2024" {
+		t.Errorf("Author = %q", s.Author)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/RafaelPiloto10/base16-terminal-sexy/base16"
+	"github.com/RafaelPiloto10/base16-terminal-sexy/internal/yaml"
+)
+
+// configDir holds the user's settings and template overrides, relative to
+// the home directory.
+const configDir = ".config/base16-terminal-sexy"
+
+// config holds the settings read from configDir/config.yaml:
+//
+//	neovim-plugins: [telescope, gitsigns]
+//
+// Flags given on the command line take precedence.
+type config struct {
+	NeovimPlugins []string
+}
+
+// loadConfig reads the config file under home. A missing file is an empty
+// config.
+func loadConfig(home string) (config, error) {
+	path := fmt.Sprintf("%s/%s/config.yaml", home, configDir)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config{}, nil
+	} else if err != nil {
+		return config{}, &InputError{Path: path, Msg: "cannot read config file", Err: err}
+	}
+
+	doc, err := yaml.Parse(data)
+	if err != nil {
+		return config{}, &InputError{Path: path, Msg: "invalid config file", Err: err}
+	}
+	if doc.Kind != yaml.Mapping {
+		return config{}, &InputError{Path: path, Msg: "invalid config file", Err: fmt.Errorf("line %d: expecting a mapping", doc.Line)}
+	}
+
+	var c config
+	for _, p := range doc.Pairs {
+		switch p.Key {
+		case "neovim-plugins":
+			c.NeovimPlugins = listValue(p.Value)
+		default:
+			return config{}, &InputError{Path: path, Msg: "invalid config file", Err: fmt.Errorf("line %d: unknown key %q", p.Line, p.Key)}
+		}
+	}
+	return c, nil
+}
+
+// listValue reads a YAML sequence, or a scalar as a comma separated list.
+func listValue(n *yaml.Node) []string {
+	if n.Kind != yaml.Sequence {
+		return splitList(n.Value)
+	}
+	var list []string
+	for _, item := range n.Items {
+		list = append(list, item.Scalar())
+	}
+	return list
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// neovimOptions returns the neovim options from the flags, falling back to
+// the config file, and the highlight overrides for the scheme. "all"
+// selects every plugin pack.
+func neovimOptions(home, slug string) (base16.NeovimOptions, error) {
+	plugins := splitList(*neovimPlugins)
+	var path string
+	if *neovimPlugins == "" {
+		c, err := loadConfig(home)
+		if err != nil {
+			return base16.NeovimOptions{}, err
+		}
+		plugins = c.NeovimPlugins
+		path = fmt.Sprintf("%s/%s/config.yaml", home, configDir)
+	}
+
+	opts := base16.NeovimOptions{Cterm256: *cterm256}
+	for _, name := range plugins {
+		if name == "all" {
+			opts.Plugins = base16.PluginNames()
+			break
+		}
+		if !isPluginName(name) {
+			msg := fmt.Sprintf("unknown plugin pack %q; choose from all, %s", name, strings.Join(base16.PluginNames(), ", "))
+			if path == "" {
+				msg = "-neovim-plugins: " + msg
+			}
+			return base16.NeovimOptions{}, &InputError{Path: path, Msg: msg}
+		}
+		opts.Plugins = append(opts.Plugins, name)
+	}
+
+	// Global overrides come first so that the scheme's own file wins.
+	for _, path := range []string{
+		fmt.Sprintf("%s/%s/highlights.yaml", home, configDir),
+		fmt.Sprintf("%s/%s/highlights/%s.yaml", home, configDir, slug),
+	} {
+		overrides, err := loadOverrides(path)
+		if err != nil {
+			return base16.NeovimOptions{}, err
+		}
+		opts.Overrides = append(opts.Overrides, overrides...)
+	}
+	return opts, nil
+}
+
+// loadOverrides reads a highlight overrides file; a missing file has none.
+func loadOverrides(path string) ([]base16.HighlightOverride, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, &InputError{Path: path, Msg: "cannot read highlight overrides", Err: err}
+	}
+	overrides, err := base16.ParseHighlightOverrides(data)
+	if err != nil {
+		return nil, &InputError{Path: path, Msg: "invalid highlight overrides", Err: err}
+	}
+	return overrides, nil
+}
+
+func isPluginName(name string) bool {
+	for _, n := range base16.PluginNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/RafaelPiloto10/base16-terminal-sexy/base16"
+)
+
+// Exit codes reported for each error category.
+const (
+	exitOK         = 0
+	exitInternal   = 1
+	exitInput      = 2
+	exitValidation = 3
+	exitWrite      = 4
+)
+
+// categorized is implemented by every error the CLI reports to the user.
+type categorized interface {
+	error
+	Category() string
+	ExitCode() int
+}
+
+// InputError reports a missing, unreadable or undecodable input file.
+type InputError struct {
+	Path string
+	Msg  string
+	Err  error
+}
+
+func (e *InputError) Error() string {
+	if e.Path == "" {
+		return joinError(e.Msg, e.Err)
+	}
+	return joinError(fmt.Sprintf("input %s: %s", quoted(e.Path), e.Msg), e.Err)
+}
+
+func (e *InputError) Unwrap() error    { return e.Err }
+func (e *InputError) Category() string { return "input" }
+func (e *InputError) ExitCode() int    { return exitInput }
+
+// ValidationError reports a theme that was read but is not usable.
+type ValidationError struct {
+	Path string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	var ps base16.Problems
+	if !errors.As(e.Err, &ps) {
+		return fmt.Sprintf("invalid theme %s: %v", quoted(e.Path), e.Err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid theme %s: %d problem(s)", quoted(e.Path), len(ps))
+	for _, p := range ps {
+		fmt.Fprintf(&b, "\n  %s", p)
+	}
+	return b.String()
+}
+
+func (e *ValidationError) Unwrap() error    { return e.Err }
+func (e *ValidationError) Category() string { return "validation" }
+func (e *ValidationError) ExitCode() int    { return exitValidation }
+
+// WriteError reports a generated file that could not be written.
+type WriteError struct {
+	Path string
+	Msg  string
+	Err  error
+}
+
+func (e *WriteError) Error() string {
+	return joinError(fmt.Sprintf("cannot write %s: %s", quoted(e.Path), e.Msg), e.Err)
+}
+
+func (e *WriteError) Unwrap() error    { return e.Err }
+func (e *WriteError) Category() string { return "write" }
+func (e *WriteError) ExitCode() int    { return exitWrite }
+
+func joinError(msg string, err error) string {
+	if err == nil {
+		return msg
+	}
+	return fmt.Sprintf("%s: %v", msg, err)
+}
+
+func quoted(path string) string {
+	if path == "" {
+		return "(none)"
+	}
+	return fmt.Sprintf("%q", path)
+}
+
+func exitCode(err error) int {
+	var c categorized
+	if errors.As(err, &c) {
+		return c.ExitCode()
+	}
+	return exitInternal
+}
+
+func category(err error) string {
+	var c categorized
+	if errors.As(err, &c) {
+		return c.Category()
+	}
+	return "internal"
+}
+
+// reportError writes err to w, either as a single line of text or, with
+// asJSON, as an object scripts can decode.
+func reportError(w io.Writer, err error, asJSON bool) {
+	if !asJSON {
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+
+	type problem struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+	var problems []problem
+	var ps base16.Problems
+	if errors.As(err, &ps) {
+		for _, p := range ps {
+			problems = append(problems, problem{p.Field, p.Message})
+		}
+	}
+
+	json.NewEncoder(w).Encode(struct {
+		Category string    `json:"category"`
+		Code     int       `json:"code"`
+		Message  string    `json:"message"`
+		Problems []problem `json:"problems,omitempty"`
+	}{category(err), exitCode(err), err.Error(), problems})
+}
@@ -0,0 +1,3 @@
+module github.com/RafaelPiloto10/base16-terminal-sexy
+
+go 1.21
@@ -0,0 +1,326 @@
+// Package mustache implements the Mustache template language as used by
+// base16 builder templates: variables, sections, inverted sections,
+// comments, partials and delimiter changes. Lambdas are not supported.
+package mustache
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+)
+
+type nodeKind int
+
+const (
+	textNode nodeKind = iota
+	varNode
+	rawNode
+	sectionNode
+	invertedNode
+	partialNode
+)
+
+type node struct {
+	kind     nodeKind
+	text     string // literal text, or the tag name
+	children []*node
+	line     int
+}
+
+// Template is a parsed Mustache template.
+type Template struct {
+	nodes []*node
+}
+
+// Error reports a template syntax or rendering error and its line.
+type Error struct {
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// Parse parses a template.
+func Parse(src string) (*Template, error) {
+	p := &parser{src: src, otag: "{{", ctag: "}}"}
+	nodes, err := p.parse("")
+	if err != nil {
+		return nil, err
+	}
+	return &Template{nodes: nodes}, nil
+}
+
+// Render parses src and renders it against data.
+func Render(src string, data any, partials map[string]string) (string, error) {
+	t, err := Parse(src)
+	if err != nil {
+		return "", err
+	}
+	return t.Render(data, partials)
+}
+
+type parser struct {
+	src        string
+	pos        int
+	otag, ctag string
+}
+
+func (p *parser) line(pos int) int {
+	return strings.Count(p.src[:pos], "\n") + 1
+}
+
+// parse reads nodes until the closing tag for section, or the end of the
+// template if section is empty.
+func (p *parser) parse(section string) ([]*node, error) {
+	var nodes []*node
+	text := func(s string) {
+		if s != "" {
+			nodes = append(nodes, &node{kind: textNode, text: s})
+		}
+	}
+
+	for {
+		i := strings.Index(p.src[p.pos:], p.otag)
+		if i < 0 {
+			text(p.src[p.pos:])
+			p.pos = len(p.src)
+			if section != "" {
+				return nil, &Error{p.line(len(p.src)), fmt.Sprintf("unclosed section %q", section)}
+			}
+			return nodes, nil
+		}
+
+		start := p.pos + i
+		line := p.line(start)
+		inner := start + len(p.otag)
+		closing := p.ctag
+		var sigil byte
+		if inner < len(p.src) {
+			sigil = p.src[inner]
+		}
+		switch sigil {
+		case '{':
+			closing = "}" + p.ctag
+		case '=':
+			closing = "=" + p.ctag
+		}
+		j := strings.Index(p.src[inner:], closing)
+		if j < 0 {
+			return nil, &Error{line, fmt.Sprintf("unclosed tag %q", p.otag)}
+		}
+		end := inner + j + len(closing)
+		content := p.src[inner : inner+j]
+
+		name := ""
+		switch sigil {
+		case '#', '^', '/', '>', '&', '{', '=', '!':
+			if content == "" {
+				return nil, &Error{line, fmt.Sprintf("empty tag %q", p.src[start:end])}
+			}
+			name = strings.TrimSpace(content[1:])
+		default:
+			sigil = 0
+			name = strings.TrimSpace(content)
+		}
+
+		// Tags that produce no output and sit alone on their line take the
+		// whole line with them.
+		textEnd := start
+		switch sigil {
+		case '#', '^', '/', '>', '=', '!':
+			if ls, le, ok := p.standalone(start, end); ok {
+				textEnd, end = ls, le
+			}
+		}
+		text(p.src[p.pos:textEnd])
+		p.pos = end
+
+		switch sigil {
+		case '!':
+		case '=':
+			delims := strings.Fields(strings.TrimSuffix(name, "="))
+			if len(delims) != 2 {
+				return nil, &Error{line, fmt.Sprintf("invalid delimiters %q", name)}
+			}
+			p.otag, p.ctag = delims[0], delims[1]
+		case '#', '^':
+			children, err := p.parse(name)
+			if err != nil {
+				return nil, err
+			}
+			kind := sectionNode
+			if sigil == '^' {
+				kind = invertedNode
+			}
+			nodes = append(nodes, &node{kind: kind, text: name, children: children, line: line})
+		case '/':
+			if name != section {
+				if section == "" {
+					return nil, &Error{line, fmt.Sprintf("unexpected closing tag %q", name)}
+				}
+				return nil, &Error{line, fmt.Sprintf("closing tag %q does not match section %q", name, section)}
+			}
+			return nodes, nil
+		case '>':
+			nodes = append(nodes, &node{kind: partialNode, text: name, line: line})
+		case '&', '{':
+			nodes = append(nodes, &node{kind: rawNode, text: name, line: line})
+		default:
+			nodes = append(nodes, &node{kind: varNode, text: name, line: line})
+		}
+	}
+}
+
+// standalone reports whether the tag from start to end is the only thing on
+// its line besides whitespace, and if so returns the bounds of that line.
+func (p *parser) standalone(start, end int) (lineStart, lineEnd int, ok bool) {
+	lineStart = strings.LastIndexByte(p.src[:start], '\n') + 1
+	if lineStart < p.pos || strings.Trim(p.src[lineStart:start], " \t") != "" {
+		return 0, 0, false
+	}
+	lineEnd = len(p.src)
+	if nl := strings.IndexByte(p.src[end:], '\n'); nl >= 0 {
+		lineEnd = end + nl + 1
+	}
+	if strings.Trim(p.src[end:lineEnd], " \t\r\n") != "" {
+		return 0, 0, false
+	}
+	return lineStart, lineEnd, true
+}
+
+// Render renders the template against data, which is usually a
+// map[string]any. Partials are looked up by name in partials.
+func (t *Template) Render(data any, partials map[string]string) (string, error) {
+	r := &renderer{partials: partials}
+	if err := r.render(t.nodes, []any{data}); err != nil {
+		return "", err
+	}
+	return r.out.String(), nil
+}
+
+type renderer struct {
+	out      strings.Builder
+	partials map[string]string
+	depth    int
+}
+
+func (r *renderer) render(nodes []*node, stack []any) error {
+	for _, n := range nodes {
+		switch n.kind {
+		case textNode:
+			r.out.WriteString(n.text)
+		case varNode, rawNode:
+			v, _ := lookup(stack, n.text)
+			if v == nil {
+				continue
+			}
+			s := fmt.Sprint(v)
+			if n.kind == varNode {
+				s = html.EscapeString(s)
+			}
+			r.out.WriteString(s)
+		case sectionNode:
+			v, _ := lookup(stack, n.text)
+			if !truthy(v) {
+				continue
+			}
+			rv := reflect.ValueOf(v)
+			if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+				for i := 0; i < rv.Len(); i++ {
+					if err := r.render(n.children, append(stack, rv.Index(i).Interface())); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err := r.render(n.children, append(stack, v)); err != nil {
+				return err
+			}
+		case invertedNode:
+			v, _ := lookup(stack, n.text)
+			if !truthy(v) {
+				if err := r.render(n.children, stack); err != nil {
+					return err
+				}
+			}
+		case partialNode:
+			src, ok := r.partials[n.text]
+			if !ok {
+				continue
+			}
+			if r.depth > 32 {
+				return &Error{n.line, fmt.Sprintf("partial %q nests too deeply", n.text)}
+			}
+			t, err := Parse(src)
+			if err != nil {
+				return fmt.Errorf("partial %q: %w", n.text, err)
+			}
+			r.depth++
+			err = r.render(t.nodes, stack)
+			r.depth--
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookup resolves a possibly dotted name against the context stack,
+// innermost context first.
+func lookup(stack []any, name string) (any, bool) {
+	if name == "." {
+		return stack[len(stack)-1], true
+	}
+
+	parts := strings.Split(name, ".")
+	for i := len(stack) - 1; i >= 0; i-- {
+		v, ok := field(stack[i], parts[0])
+		if !ok {
+			continue
+		}
+		for _, part := range parts[1:] {
+			if v, ok = field(v, part); !ok {
+				return nil, false
+			}
+		}
+		return v, true
+	}
+	return nil, false
+}
+
+func field(ctx any, name string) (any, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	rv := reflect.ValueOf(ctx)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	v := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+	if !v.IsValid() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+func truthy(v any) bool {
+	if v == nil {
+		return false
+	}
+	switch x := v.(type) {
+	case bool:
+		return x
+	case string:
+		return x != ""
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() > 0
+	}
+	return true
+}
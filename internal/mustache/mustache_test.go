@@ -0,0 +1,118 @@
+package mustache
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	data := map[string]any{
+		"name":   "Tomorrow Night",
+		"html":   `<a href="x">&'</a>`,
+		"empty":  "",
+		"yes":    true,
+		"no":     false,
+		"list":   []any{"a", "b", "c"},
+		"none":   []any{},
+		"items":  []map[string]any{{"n": "1"}, {"n": "2"}},
+		"scheme": map[string]any{"base00": map[string]any{"hex": "1d1f21"}},
+		"n":      "outer",
+	}
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"variable", "Hi {{name}}!", "Hi Tomorrow Night!"},
+		{"spaces in tag", "{{ name }}", "Tomorrow Night"},
+		{"missing variable", "[{{missing}}]", "[]"},
+		{"escaped", "{{html}}", "&lt;a href=&#34;x&#34;&gt;&amp;&#39;&lt;/a&gt;"},
+		{"triple mustache", "{{{html}}}", `<a href="x">&'</a>`},
+		{"ampersand", "{{& html}}", `<a href="x">&'</a>`},
+		{"comment", "a{{! ignored }}b", "ab"},
+
+		{"section true", "{{#yes}}on{{/yes}}", "on"},
+		{"section false", "{{#no}}on{{/no}}", ""},
+		{"section empty string", "{{#empty}}on{{/empty}}", ""},
+		{"section list", "{{#list}}<{{.}}>{{/list}}", "<a><b><c>"},
+		{"section empty list", "{{#none}}x{{/none}}", ""},
+		{"section context", "{{#items}}{{n}},{{/items}}", "1,2,"},
+		{"section outer lookup", "{{#yes}}{{n}}{{/yes}}", "outer"},
+		{"inverted false", "{{^no}}off{{/no}}", "off"},
+		{"inverted missing", "{{^missing}}off{{/missing}}", "off"},
+		{"inverted empty list", "{{^none}}none{{/none}}", "none"},
+		{"inverted true", "{{^yes}}off{{/yes}}", ""},
+		{"inverted list", "{{^list}}off{{/list}}", ""},
+
+		{"dotted", "{{scheme.base00.hex}}", "1d1f21"},
+		{"dotted section", "{{#scheme.base00}}{{hex}}{{/scheme.base00}}", "1d1f21"},
+		{"dotted missing", "[{{scheme.base01.hex}}]", "[]"},
+		{"dotted broken chain", "[{{#items}}{{name.x}}{{/items}}]", "[]"},
+
+		{"delimiters", "{{=<% %>=}}<% name %> {{name}}", "Tomorrow Night {{name}}"},
+		{"delimiters reset", "{{=| |=}}|name| |={{ }}=|{{name}}", "Tomorrow Night Tomorrow Night"},
+		{"delimiters raw", "{{=[ ]=}}[&html]", `<a href="x">&'</a>`},
+
+		{"standalone section", "a\n{{#yes}}\nb\n{{/yes}}\nc\n", "a\nb\nc\n"},
+		{"standalone indented", "a\n  {{#list}}\n{{.}}\n  {{/list}}\n", "a\na\nb\nc\n"},
+		{"standalone inverted", "{{^no}}\nx\n{{/no}}\n", "x\n"},
+		{"standalone comment", "a\n  {{! note }}\nb", "a\nb"},
+		{"standalone delimiters", "a\n{{=<% %>=}}\n<%name%>", "a\nTomorrow Night"},
+		{"standalone crlf", "{{#yes}}\r\nx\r\n{{/yes}}\r\n", "x\r\n"},
+		{"standalone last line", "x\n{{#yes}}{{/yes}}", "x\n"},
+		{"not standalone", "a {{#yes}}\nb{{/yes}}", "a \nb"},
+		{"variable not standalone", "{{name}}\n", "Tomorrow Night\n"},
+	}
+	for _, tt := range tests {
+		got, err := Render(tt.in, data, nil)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPartials(t *testing.T) {
+	partials := map[string]string{
+		"item": "[{{.}}]",
+		"loop": "{{> loop}}",
+	}
+	got, err := Render("{{#list}}{{> item}}{{/list}}{{> missing}}", map[string]any{"list": []string{"a", "b"}}, partials)
+	if err != nil || got != "[a][b]" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if _, err := Render("{{> loop}}", nil, partials); err == nil {
+		t.Error("recursive partial rendered without an error")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		line int
+		msg  string
+	}{
+		{"unclosed tag", "a\n{{name", 2, `unclosed tag "{{"`},
+		{"unclosed triple", "{{{name}}", 1, `unclosed tag "{{"`},
+		{"unclosed section", "{{#a}}\nx\n", 3, `unclosed section "a"`},
+		{"stray closing tag", "x\n{{/a}}", 2, `unexpected closing tag "a"`},
+		{"mismatched closing tag", "{{#a}}\n{{/b}}", 2, `closing tag "b" does not match section "a"`},
+		{"bad delimiters", "{{=<%=}}", 1, `invalid delimiters "<%"`},
+		{"empty delimiter tag", "a\n{{=}}", 2, `empty tag "{{=}}"`},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.in)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%s: error = %v, want an *Error", tt.name, err)
+			continue
+		}
+		if e.Line != tt.line || e.Msg != tt.msg {
+			t.Errorf("%s: line %d %q, want line %d %q", tt.name, e.Line, e.Msg, tt.line, tt.msg)
+		}
+	}
+}
@@ -0,0 +1,472 @@
+// Package yaml parses the subset of YAML used by base16 scheme files and
+// this tool's configuration: block mappings and sequences, flow mappings and
+// sequences, and plain or quoted scalars. Anchors, tags, multi-document
+// streams and block scalars are not supported.
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is the type of a Node.
+type Kind int
+
+const (
+	Scalar Kind = iota
+	Mapping
+	Sequence
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Scalar:
+		return "scalar"
+	case Mapping:
+		return "mapping"
+	case Sequence:
+		return "sequence"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Node is a parsed YAML value. Mappings keep their keys in document order.
+type Node struct {
+	Kind  Kind
+	Line  int
+	Value string
+	Pairs []Pair
+	Items []*Node
+}
+
+// Pair is one key of a mapping.
+type Pair struct {
+	Key   string
+	Line  int
+	Value *Node
+}
+
+// Get returns the value for key, or nil if n is not a mapping or has no
+// such key.
+func (n *Node) Get(key string) *Node {
+	if n == nil || n.Kind != Mapping {
+		return nil
+	}
+	for _, p := range n.Pairs {
+		if p.Key == key {
+			return p.Value
+		}
+	}
+	return nil
+}
+
+// Scalar returns the value of a scalar node, or "" if n is nil or not a
+// scalar.
+func (n *Node) Scalar() string {
+	if n == nil || n.Kind != Scalar {
+		return ""
+	}
+	return n.Value
+}
+
+// Error reports a syntax error and the line it occurred on.
+type Error struct {
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+type line struct {
+	num    int
+	indent int
+	text   string
+}
+
+type parser struct {
+	lines []line
+	pos   int
+}
+
+// Parse parses a YAML document. An empty document yields an empty mapping.
+func Parse(data []byte) (*Node, error) {
+	p := &parser{}
+	for i, raw := range strings.Split(string(data), "\n") {
+		raw = strings.TrimRight(raw, " \t\r")
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, &Error{i + 1, "tabs are not allowed for indentation"}
+		}
+		text = stripComment(text)
+		if text == "" || text == "---" || strings.HasPrefix(text, "%") {
+			continue
+		}
+		if text == "..." {
+			break
+		}
+		p.lines = append(p.lines, line{num: i + 1, indent: len(raw) - len(strings.TrimLeft(raw, " ")), text: text})
+	}
+
+	if len(p.lines) == 0 {
+		return &Node{Kind: Mapping, Line: 1}, nil
+	}
+	n, err := p.block(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		return nil, &Error{l.num, "unexpected indentation"}
+	}
+	return n, nil
+}
+
+// block parses the mapping or sequence whose entries start at indent.
+func (p *parser) block(indent int) (*Node, error) {
+	first := p.lines[p.pos]
+	if isItem(first.text) {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent)
+}
+
+func (p *parser) sequence(indent int) (*Node, error) {
+	n := &Node{Kind: Sequence, Line: p.lines[p.pos].num}
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, &Error{l.num, "unexpected indentation"}
+		}
+		if !isItem(l.text) {
+			break
+		}
+
+		rest := strings.TrimLeft(l.text[1:], " ")
+		if rest == "" {
+			p.pos++
+			item, err := p.nested(l)
+			if err != nil {
+				return nil, err
+			}
+			n.Items = append(n.Items, item)
+			continue
+		}
+
+		// "- key: value" starts a mapping indented to the item's content.
+		if _, _, ok := splitKey(rest); ok && !isFlow(rest) {
+			itemIndent := l.indent + len(l.text) - len(rest)
+			p.lines[p.pos] = line{num: l.num, indent: itemIndent, text: rest}
+			item, err := p.mapping(itemIndent)
+			if err != nil {
+				return nil, err
+			}
+			n.Items = append(n.Items, item)
+			continue
+		}
+
+		p.pos++
+		item, err := parseValue(rest, l.num)
+		if err != nil {
+			return nil, err
+		}
+		n.Items = append(n.Items, item)
+	}
+	return n, nil
+}
+
+func (p *parser) mapping(indent int) (*Node, error) {
+	n := &Node{Kind: Mapping, Line: p.lines[p.pos].num}
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, &Error{l.num, "unexpected indentation"}
+		}
+		if isItem(l.text) {
+			return nil, &Error{l.num, "unexpected sequence item in mapping"}
+		}
+
+		key, rest, ok := splitKey(l.text)
+		if !ok {
+			return nil, &Error{l.num, fmt.Sprintf("expecting \"key: value\", got %q", l.text)}
+		}
+		if n.Get(key) != nil {
+			return nil, &Error{l.num, fmt.Sprintf("duplicate key %q", key)}
+		}
+		p.pos++
+
+		var value *Node
+		var err error
+		if rest == "" {
+			value, err = p.nested(l)
+		} else {
+			value, err = parseValue(rest, l.num)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.Pairs = append(n.Pairs, Pair{Key: key, Line: l.num, Value: value})
+	}
+	return n, nil
+}
+
+// nested parses the block belonging to the key or item on parent. A
+// sequence may sit at the same indentation as its parent key.
+func (p *parser) nested(parent line) (*Node, error) {
+	if p.pos < len(p.lines) {
+		next := p.lines[p.pos]
+		if next.indent > parent.indent || (next.indent == parent.indent && isItem(next.text) && !isItem(parent.text)) {
+			return p.block(next.indent)
+		}
+	}
+	return &Node{Kind: Scalar, Line: parent.num}, nil
+}
+
+func isItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func isFlow(text string) bool {
+	return strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[")
+}
+
+// splitKey splits "key: value" into its key and the remaining text.
+func splitKey(text string) (key, rest string, ok bool) {
+	if text[0] == '"' || text[0] == '\'' {
+		end := closingQuote(text)
+		if end < 0 || end+1 >= len(text) || text[end+1] != ':' {
+			return "", "", false
+		}
+		key, err := unquote(text[:end+1])
+		if err != nil {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(text[end+2:]), true
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), i > 0
+		}
+	}
+	return "", "", false
+}
+
+// stripComment removes a trailing "# comment" that is not inside quotes.
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.ContainsRune(" :[{,-", rune(text[i-1])) {
+				quote = c
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' '):
+			return strings.TrimRight(text[:i], " ")
+		}
+	}
+	return text
+}
+
+func closingQuote(text string) int {
+	q := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case q == '"' && text[i] == '\\':
+			i++
+		case q == '\'' && text[i] == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == q:
+			return i
+		}
+	}
+	return -1
+}
+
+func unquote(text string) (string, error) {
+	if text[0] == '\'' {
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+	return strconv.Unquote(text)
+}
+
+// parseValue parses an inline value: a quoted or plain scalar, or a flow
+// collection.
+func parseValue(text string, num int) (*Node, error) {
+	f := &flow{text: text, num: num}
+	n, err := f.value()
+	if err != nil {
+		return nil, err
+	}
+	f.skipSpace()
+	if f.pos != len(f.text) {
+		return nil, &Error{num, fmt.Sprintf("unexpected %q", f.text[f.pos:])}
+	}
+	return n, nil
+}
+
+type flow struct {
+	text  string
+	pos   int
+	num   int
+	depth int // flow collections open at pos
+}
+
+func (f *flow) skipSpace() {
+	for f.pos < len(f.text) && f.text[f.pos] == ' ' {
+		f.pos++
+	}
+}
+
+func (f *flow) value() (*Node, error) {
+	f.skipSpace()
+	if f.pos == len(f.text) {
+		return &Node{Kind: Scalar, Line: f.num}, nil
+	}
+	switch f.text[f.pos] {
+	case '[':
+		return f.sequence()
+	case '{':
+		return f.mapping()
+	case '"', '\'':
+		s, err := f.quoted()
+		if err != nil {
+			return nil, err
+		}
+		return &Node{Kind: Scalar, Line: f.num, Value: s}, nil
+	case '|', '>':
+		return nil, &Error{f.num, "block scalars are not supported"}
+	case '&', '*', '!':
+		return nil, &Error{f.num, "anchors, aliases and tags are not supported"}
+	}
+	return &Node{Kind: Scalar, Line: f.num, Value: f.plain()}, nil
+}
+
+func (f *flow) quoted() (string, error) {
+	end := closingQuote(f.text[f.pos:])
+	if end < 0 {
+		return "", &Error{f.num, "unterminated string"}
+	}
+	s, err := unquote(f.text[f.pos : f.pos+end+1])
+	if err != nil {
+		return "", &Error{f.num, fmt.Sprintf("invalid string %s", f.text[f.pos:f.pos+end+1])}
+	}
+	f.pos += end + 1
+	return s, nil
+}
+
+// plain reads an unquoted scalar. Inside flow collections it stops at
+// ',', ']' and '}'.
+func (f *flow) plain() string {
+	start := f.pos
+	for f.pos < len(f.text) {
+		c := f.text[f.pos]
+		if f.depth > 0 && (c == ',' || c == ']' || c == '}') {
+			break
+		}
+		if c == ':' && (f.pos+1 == len(f.text) || f.text[f.pos+1] == ' ') {
+			break
+		}
+		f.pos++
+	}
+	return strings.TrimSpace(f.text[start:f.pos])
+}
+
+func (f *flow) sequence() (*Node, error) {
+	n := &Node{Kind: Sequence, Line: f.num}
+	f.pos++
+	f.depth++
+	defer func() { f.depth-- }()
+	for {
+		f.skipSpace()
+		if f.pos == len(f.text) {
+			return nil, &Error{f.num, "missing ']'"}
+		}
+		if f.text[f.pos] == ']' {
+			f.pos++
+			return n, nil
+		}
+		item, err := f.value()
+		if err != nil {
+			return nil, err
+		}
+		n.Items = append(n.Items, item)
+		if err := f.separator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (f *flow) mapping() (*Node, error) {
+	n := &Node{Kind: Mapping, Line: f.num}
+	f.pos++
+	f.depth++
+	defer func() { f.depth-- }()
+	for {
+		f.skipSpace()
+		if f.pos == len(f.text) {
+			return nil, &Error{f.num, "missing '}'"}
+		}
+		if f.text[f.pos] == '}' {
+			f.pos++
+			return n, nil
+		}
+
+		var key string
+		if c := f.text[f.pos]; c == '"' || c == '\'' {
+			k, err := f.quoted()
+			if err != nil {
+				return nil, err
+			}
+			key = k
+		} else {
+			key = f.plain()
+		}
+		f.skipSpace()
+		if f.pos == len(f.text) || f.text[f.pos] != ':' {
+			return nil, &Error{f.num, fmt.Sprintf("expecting ':' after key %q", key)}
+		}
+		f.pos++
+		if n.Get(key) != nil {
+			return nil, &Error{f.num, fmt.Sprintf("duplicate key %q", key)}
+		}
+
+		value, err := f.value()
+		if err != nil {
+			return nil, err
+		}
+		n.Pairs = append(n.Pairs, Pair{Key: key, Line: f.num, Value: value})
+		if err := f.separator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (f *flow) separator(end byte) error {
+	f.skipSpace()
+	if f.pos < len(f.text) {
+		switch f.text[f.pos] {
+		case ',':
+			f.pos++
+			return nil
+		case end:
+			return nil
+		}
+	}
+	return &Error{f.num, fmt.Sprintf("expecting ',' or '%c'", end)}
+}
@@ -0,0 +1,145 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// dump renders n in flow style, quoting scalars, so trees compare as
+// strings.
+func dump(n *Node) string {
+	switch n.Kind {
+	case Mapping:
+		var pairs []string
+		for _, p := range n.Pairs {
+			pairs = append(pairs, fmt.Sprintf("%q: %s", p.Key, dump(p.Value)))
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	case Sequence:
+		var items []string
+		for _, item := range n.Items {
+			items = append(items, dump(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return fmt.Sprintf("%q", n.Value)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", `{}`},
+		{"comments only", "# nothing\n\n---\n", `{}`},
+		{"block mapping", "scheme: Tomorrow\nauthor: Chris Kempson\n", `{"scheme": "Tomorrow", "author": "Chris Kempson"}`},
+		{"nested mapping", "a:\n  b: 1\n  c:\n    d: 2\ne: 3\n", `{"a": {"b": "1", "c": {"d": "2"}}, "e": "3"}`},
+		{"empty value", "a:\nb: 1\n", `{"a": "", "b": "1"}`},
+		{"block sequence", "- one\n- two\n", `["one", "two"]`},
+		{"indented sequence", "list:\n  - one\n  - two\n", `{"list": ["one", "two"]}`},
+		{"same-indent sequence", "list:\n- one\n- two\nnext: x\n", `{"list": ["one", "two"], "next": "x"}`},
+		{"sequence of mappings", "- name: a\n  value: 1\n- name: b\n", `[{"name": "a", "value": "1"}, {"name": "b"}]`},
+		{"nested sequence item", "-\n  - a\n  - b\n- c\n", `[["a", "b"], "c"]`},
+		{"flow sequence", "a: [x, 'y', \"z\"]\n", `{"a": ["x", "y", "z"]}`},
+		{"flow mapping", "a: {fg: base05, bg: \"base00\"}\n", `{"a": {"fg": "base05", "bg": "base00"}}`},
+		{"nested flow", "a: {b: [1, {c: 2}], d: []}\n", `{"a": {"b": ["1", {"c": "2"}], "d": []}}`},
+		{"empty flow mapping", "a: {}\n", `{"a": {}}`},
+		{"double quoted key", "\"base 00\": x\n", `{"base 00": "x"}`},
+		{"single quoted key", "'a: b': x\n", `{"a: b": "x"}`},
+		{"double quoted escapes", `a: "tab\there \"q\""` + "\n", `{"a": "tab\there \"q\""}`},
+		{"single quoted escapes", "a: 'it''s'\n", `{"a": "it's"}`},
+		{"trailing comment", "a: x # note\nb: \"#fff\" # hex\n", `{"a": "x", "b": "#fff"}`},
+		{"hash inside value", "a: x#y\nb: '# not a comment'\n", `{"a": "x#y", "b": "# not a comment"}`},
+		{"colon inside value", "url: http://example.com\n", `{"url": "http://example.com"}`},
+		{"comma in plain value", "author: Chris Kempson (http://chriskempson.com), ported\n", `{"author": "Chris Kempson (http://chriskempson.com), ported"}`},
+		{"brackets in plain value", "scheme: Foo [bar] {baz}\n", `{"scheme": "Foo [bar] {baz}"}`},
+		{"comma in plain item", "- a, b\n- c]\n", `["a, b", "c]"]`},
+		{"comma in flow value", "a: [x, {b: c, d: e}]\n", `{"a": ["x", {"b": "c", "d": "e"}]}`},
+		{"document end", "a: 1\n...\nb: 2\n", `{"a": "1"}`},
+		{"crlf", "a: 1\r\nb: 2\r\n", `{"a": "1", "b": "2"}`},
+	}
+	for _, tt := range tests {
+		n, err := Parse([]byte(tt.in))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := dump(n); got != tt.want {
+			t.Errorf("%s:\ngot  %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseLines(t *testing.T) {
+	n, err := Parse([]byte("# header\nscheme: x\n\nslots:\n  base00: a\n  base01: {x: 1}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slots := n.Get("slots")
+	if n.Pairs[0].Line != 2 || n.Pairs[1].Line != 4 || slots.Line != 5 {
+		t.Errorf("lines %d, %d, %d; want 2, 4, 5", n.Pairs[0].Line, n.Pairs[1].Line, slots.Line)
+	}
+	if p := slots.Pairs[1]; p.Line != 6 || p.Value.Get("x").Line != 6 {
+		t.Errorf("base01 on line %d, its value on %d; want 6", p.Line, p.Value.Get("x").Line)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		line int
+		msg  string
+	}{
+		{"duplicate key", "a: 1\nb: 2\na: 3\n", 3, `duplicate key "a"`},
+		{"duplicate nested key", "a:\n  b: 1\n  b: 2\n", 3, `duplicate key "b"`},
+		{"duplicate flow key", "x: 1\na: {b: 1, b: 2}\n", 2, `duplicate key "b"`},
+		{"tab indent", "a:\n\tb: 1\n", 2, "tabs are not allowed for indentation"},
+		{"over-indented", "a: 1\n  b: 2\n", 2, "unexpected indentation"},
+		{"trailing over-indent", "a:\n    b: 1\n  c: 2\n", 3, "unexpected indentation"},
+		{"item in mapping", "a: 1\n- b\n", 2, "unexpected sequence item in mapping"},
+		{"not a key", "a: 1\njust text\n", 2, `expecting "key: value", got "just text"`},
+		{"unclosed flow sequence", "a: 1\nb: [x,\n", 2, "missing ']'"},
+		{"unclosed flow mapping", "b: {x: 1,\n", 1, "missing '}'"},
+		{"unfinished flow sequence", "b: [x, y\n", 1, "expecting ',' or ']'"},
+		{"flow key without colon", "b: {x}\n", 1, `expecting ':' after key "x"`},
+		{"flow separator", "b: [\"x\" y]\n", 1, "expecting ',' or ']'"},
+		{"unterminated string", "a: 1\nb: \"open\n", 2, "unterminated string"},
+		{"trailing text", "b: \"x\" y\n", 1, `unexpected "y"`},
+		{"block scalar", "a: |\n  text\n", 1, "block scalars are not supported"},
+		{"anchor", "a: &x 1\n", 1, "anchors, aliases and tags are not supported"},
+	}
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.in))
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%s: error = %v, want an *Error", tt.name, err)
+			continue
+		}
+		if e.Line != tt.line || e.Msg != tt.msg {
+			t.Errorf("%s: line %d %q, want line %d %q", tt.name, e.Line, e.Msg, tt.line, tt.msg)
+		}
+	}
+}
+
+func TestGetAndScalar(t *testing.T) {
+	n, err := Parse([]byte("a: x\nb: [1]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := n.Get("a").Scalar(); got != "x" {
+		t.Errorf(`Get("a").Scalar() = %q`, got)
+	}
+	if got := n.Get("b").Scalar(); got != "" {
+		t.Errorf(`Get("b").Scalar() = %q, want "" for a sequence`, got)
+	}
+	if n.Get("missing") != nil || n.Get("missing").Scalar() != "" {
+		t.Error(`Get("missing") should be nil with an empty Scalar`)
+	}
+	if n.Get("a").Get("x") != nil {
+		t.Error("Get on a scalar should be nil")
+	}
+}
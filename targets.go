@@ -0,0 +1,225 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/RafaelPiloto10/base16-terminal-sexy/base16"
+)
+
+// target is one kind of file generated from a scheme, either by rendering
+// a built-in template or by a generate function. Targets whose output
+// directory flag is empty are skipped.
+type target struct {
+	desc     string
+	dir      *string
+	file     string // file name pattern; %s is the theme name
+	template string
+	vars     func(s base16.Scheme, stem, home string) (map[string]any, error)
+	generate func(s base16.Scheme, stem string) ([]byte, error)
+	pairs    bool // also written for the opposite variant with -neovim-pair
+}
+
+var targets = []target{
+	{"vim scheme", base16NeoVimDir, "base16-%s.vim", "neovim", neovimVars, nil, true},
+	{"neovim lua scheme", neovimLuaDir, "base16-%s.lua", "neovim-lua", neovimVars, nil, true},
+	{"terminal scheme", base16TerminalDir, "base16-%s.sh", "shell", templateVars, nil, true},
+	{"alacritty theme", alacrittyDir, "base16-%s.toml", "alacritty", templateVars, nil, false},
+	{"kitty theme", kittyDir, "base16-%s.conf", "kitty", templateVars, nil, false},
+	{"wezterm scheme", weztermDir, "base16-%s.toml", "wezterm", weztermVars, nil, false},
+	{"foot theme", footDir, "base16-%s.ini", "foot", templateVars, nil, false},
+	{"ghostty theme", ghosttyDir, "base16-%s", "ghostty", templateVars, nil, false},
+	{"iterm2 colors", iterm2Dir, "base16-%s.itermcolors", "iterm2", templateVars, nil, false},
+	{"windows terminal scheme", windowsTerminalDir, "base16-%s.json", "", nil, base16.GenerateWindowsTerminalNamed, false},
+	{"base16 scheme", base16YAMLDir, "base16-%s.yaml", "", nil, yamlScheme, false},
+}
+
+// templateOverrideDir holds user copies of the built-in templates, relative
+// to the home directory. A file there replaces the built-in of the same name.
+const templateOverrideDir = configDir + "/templates"
+
+func templateVars(s base16.Scheme, stem, home string) (map[string]any, error) {
+	return base16.TemplateVars(s), nil
+}
+
+// weztermVars names the scheme after its file, which is how WezTerm users
+// select it.
+func weztermVars(s base16.Scheme, stem, home string) (map[string]any, error) {
+	return base16.WezTermVars(s, stem), nil
+}
+
+// yamlScheme writes the scheme as is; base16 YAML has no name of its own
+// to match the file.
+func yamlScheme(s base16.Scheme, stem string) ([]byte, error) {
+	return base16.GenerateYAML(s)
+}
+
+// neovimVars adds the highlight groups, the selected plugin packs and the
+// user's highlight overrides. The colorscheme is named after its file, so
+// that :colorscheme finds it.
+func neovimVars(s base16.Scheme, stem, home string) (map[string]any, error) {
+	opts, err := neovimOptions(home, s.Slug)
+	if err != nil {
+		return nil, err
+	}
+	opts.Name = stem
+	return base16.NeovimVars(s, opts)
+}
+
+// stem returns the name of the file written for name, less its extension.
+func (t target) stem(name string) string {
+	return fmt.Sprintf(strings.TrimSuffix(t.file, filepath.Ext(t.file)), name)
+}
+
+func (t target) render(scheme base16.Scheme, name, home string) ([]byte, error) {
+	if t.template == "" {
+		return t.generate(scheme, t.stem(name))
+	}
+
+	overrides := fmt.Sprintf("%s/%s", home, templateOverrideDir)
+	src, err := base16.LoadTemplate(t.template, overrides)
+	if err != nil {
+		return nil, &InputError{Path: overrides, Msg: fmt.Sprintf("cannot read %s template", t.template), Err: err}
+	}
+	vars, err := t.vars(scheme, t.stem(name), home)
+	if err != nil {
+		return nil, err
+	}
+	data, err := base16.RenderTemplateVars(src, vars)
+	if err != nil {
+		return nil, &InputError{Path: overrides, Msg: fmt.Sprintf("cannot render %s template", t.template), Err: err}
+	}
+	return data, nil
+}
+
+// write renders the target for scheme and writes it to its output folder
+// as name.
+func (t target) write(scheme base16.Scheme, name, home string) error {
+	data, err := t.render(scheme, name, home)
+	if err != nil {
+		return err
+	}
+
+	// Relative output folders are taken from the home directory.
+	dir := *t.dir
+	if !filepath.IsAbs(dir) {
+		dir = fmt.Sprintf("%s/%s", home, dir)
+	}
+	loc := fmt.Sprintf("%s/"+t.file, dir, name)
+	if sameFile(loc, *fileName) {
+		return &WriteError{Path: loc, Msg: "refusing to overwrite the input theme"}
+	}
+	if err := writeOutput(loc, data); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s to %s\n", t.desc, loc)
+	return nil
+}
+
+// sameFile reports whether a and b name the same existing file.
+func sameFile(a, b string) bool {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bi, err := os.Stat(b)
+	return err == nil && os.SameFile(ai, bi)
+}
+
+func generate(scheme base16.Scheme, name string) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return &WriteError{Msg: "cannot locate home directory", Err: err}
+	}
+
+	for _, t := range targets {
+		if *t.dir == "" {
+			continue
+		}
+		if err := t.write(scheme, name, home); err != nil {
+			return err
+		}
+		if t.pairs && *neovimPair {
+			pair := scheme.Inverted()
+			if err := t.write(pair, name+"-"+pair.Variant(), home); err != nil {
+				return err
+			}
+		}
+	}
+
+	if *templatesDir != "" {
+		return renderTemplates(scheme)
+	}
+	return nil
+}
+
+// renderTemplates renders every template in the -templates repository into
+// its output folder, creating the folder if needed.
+func renderTemplates(scheme base16.Scheme) error {
+	templates, err := base16.LoadTemplates(*templatesDir)
+	if err != nil {
+		return &InputError{Path: *templatesDir, Msg: "cannot load templates", Err: err}
+	}
+
+	out := *templatesOut
+	if out == "" {
+		out = *templatesDir
+	}
+	for _, t := range templates {
+		file, data, err := t.Render(scheme)
+		if err != nil {
+			return &InputError{Path: *templatesDir, Msg: "cannot render template", Err: err}
+		}
+
+		loc := filepath.Join(out, file)
+		if err := os.MkdirAll(filepath.Dir(loc), 0755); err != nil {
+			return &WriteError{Path: loc, Msg: "cannot create output folder", Err: err}
+		}
+		if err := writeOutput(loc, data); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s template to %s\n", t.Name, loc)
+	}
+	return nil
+}
+
+// runTemplatesExport writes the built-in templates to a folder, by default
+// the override folder, so they can be edited.
+func runTemplatesExport(args []string) error {
+	fs := flag.NewFlagSet("templates export", flag.ExitOnError)
+	dir := fs.String("dir", "", "folder to write the templates to (default ~/"+templateOverrideDir+")")
+	force := fs.Bool("force", false, "overwrite templates that already exist")
+	fs.BoolVar(jsonErrors, "json-errors", false, "report errors as JSON on stderr")
+	fs.Parse(args)
+
+	if *dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return &WriteError{Msg: "cannot locate home directory", Err: err}
+		}
+		*dir = fmt.Sprintf("%s/%s", home, templateOverrideDir)
+	}
+	if err := os.MkdirAll(*dir, 0755); err != nil {
+		return &WriteError{Path: *dir, Msg: "cannot create template folder", Err: err}
+	}
+
+	for _, name := range base16.BuiltinTemplateNames() {
+		loc := filepath.Join(*dir, name+".mustache")
+		if _, err := os.Stat(loc); err == nil && !*force {
+			return &WriteError{Path: loc, Msg: "already exists; pass -force to overwrite"}
+		}
+
+		src, err := base16.BuiltinTemplate(name)
+		if err != nil {
+			return err
+		}
+		if err := writeOutput(loc, []byte(src)); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s template to %s\n", name, loc)
+	}
+	return nil
+}